@@ -0,0 +1,296 @@
+package rio
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// AccessLog Middleware
+//
+//
+// ------------------------------------------------------------------
+
+// Apache access log formats, for use with AccessLog.
+const (
+	CommonLogFormat   = `%h %l %u %t "%r" %>s %b`
+	CombinedLogFormat = `%h %l %u %t "%r" %>s %b "%{Referer}i" "%{User-Agent}i"`
+)
+
+// accessLogTime is the timestamp format of the %t directive.
+const accessLogTime = "[02/Jan/2006:15:04:05 -0700]"
+
+// accessLogWriter captures the response status and size.
+type accessLogWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *accessLogWriter) WriteHeader(status int) {
+	if !isInformational(status) {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *accessLogWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.size += n
+	return n, err
+}
+
+// Unwrap returns the underlying ResponseWriter, so that
+// an http.ResponseController can reach it.
+func (w *accessLogWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// accessLogEntry is the data of a single access log line.
+type accessLogEntry struct {
+	r        *http.Request
+	w        *accessLogWriter
+	start    time.Time
+	duration time.Duration
+}
+
+// accessLogField writes a part of an access log line.
+type accessLogField func(b *strings.Builder, e *accessLogEntry)
+
+// AccessLog is a middleware which writes a line to w for each request,
+// in the given Apache-style format, for log pipelines which expect it.
+//
+// The supported directives are:
+//
+//	%h       remote host
+//	%l       remote logname, always "-"
+//	%u       remote user, from basic auth
+//	%t       time the request was received
+//	%r       request line
+//	%s, %>s  response status
+//	%b       response size in bytes, or "-" if zero
+//	%B       response size in bytes
+//	%D       time taken, in microseconds
+//	%T       time taken, in seconds
+//	%m       request method
+//	%U       request path
+//	%q       query string, with a leading "?"
+//	%H       request protocol
+//	%{Foo}i  request header Foo
+//	%{Foo}o  response header Foo
+//	%%       a literal "%"
+//
+// Sensitive query params and headers are redacted. The request line, user
+// and headers are escaped like Apache does, so that clients cannot forge
+// log lines. Use CommonLogFormat or
+// CombinedLogFormat for the standard formats.
+//
+//	server.Use(AccessLog(os.Stdout, CombinedLogFormat))
+//
+// .
+func AccessLog(out io.Writer, format string) func(http.Handler) http.Handler {
+	fields := parseAccessLogFormat(format)
+	var mu sync.Mutex
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := &accessLogWriter{
+				ResponseWriter: w,
+				status:         http.StatusOK,
+			}
+
+			defer func(start time.Time) {
+				e := &accessLogEntry{r: r, w: ww, start: start, duration: time.Since(start)}
+
+				var b strings.Builder
+				for _, field := range fields {
+					field(&b, e)
+				}
+				b.WriteByte('\n')
+
+				mu.Lock()
+				defer mu.Unlock()
+				io.WriteString(out, b.String())
+			}(time.Now())
+
+			next.ServeHTTP(ww, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// parseAccessLogFormat parses the format into a list of fields.
+// Unknown directives are written verbatim.
+func parseAccessLogFormat(format string) []accessLogField {
+	var fields []accessLogField
+
+	literal := func(s string) accessLogField {
+		return func(b *strings.Builder, e *accessLogEntry) { b.WriteString(s) }
+	}
+
+	for len(format) > 0 {
+		i := strings.IndexByte(format, '%')
+		if i < 0 || i == len(format)-1 {
+			fields = append(fields, literal(format))
+			break
+		}
+		if i > 0 {
+			fields = append(fields, literal(format[:i]))
+		}
+		format = format[i+1:]
+
+		// Header directives, like %{User-Agent}i.
+		if format[0] == '{' {
+			end := strings.IndexByte(format, '}')
+			if end < 0 || end == len(format)-1 {
+				fields = append(fields, literal("%"+format))
+				break
+			}
+			name, kind := format[1:end], format[end+1]
+			format = format[end+2:]
+
+			switch kind {
+			case 'i':
+				fields = append(fields, func(b *strings.Builder, e *accessLogEntry) {
+					b.WriteString(accessLogHeader(e.r.Header, name))
+				})
+			case 'o':
+				fields = append(fields, func(b *strings.Builder, e *accessLogEntry) {
+					b.WriteString(accessLogHeader(e.w.Header(), name))
+				})
+			default:
+				fields = append(fields, literal("%{"+name+"}"+string(kind)))
+			}
+			continue
+		}
+
+		directive := format[:1]
+		if strings.HasPrefix(format, ">s") {
+			directive = ">s"
+		}
+		format = format[len(directive):]
+
+		if field, ok := accessLogFields[directive]; ok {
+			fields = append(fields, field)
+		} else {
+			fields = append(fields, literal("%"+directive))
+		}
+	}
+
+	return fields
+}
+
+// accessLogFields are the fields of the single character directives.
+var accessLogFields = map[string]accessLogField{
+	"h": func(b *strings.Builder, e *accessLogEntry) {
+		host, _, err := net.SplitHostPort(e.r.RemoteAddr)
+		if err != nil {
+			host = e.r.RemoteAddr
+		}
+		b.WriteString(dashIfEmpty(host))
+	},
+	"l": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString("-")
+	},
+	"u": func(b *strings.Builder, e *accessLogEntry) {
+		user, _, _ := e.r.BasicAuth()
+		b.WriteString(dashIfEmpty(escapeAccessLog(user)))
+	},
+	"t": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(e.start.Format(accessLogTime))
+	},
+	"r": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(escapeAccessLog(fmt.Sprintf("%s %s %s", e.r.Method, redactUrl(e.r.URL), e.r.Proto)))
+	},
+	"s": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(strconv.Itoa(e.w.status))
+	},
+	">s": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(strconv.Itoa(e.w.status))
+	},
+	"b": func(b *strings.Builder, e *accessLogEntry) {
+		if e.w.size == 0 {
+			b.WriteString("-")
+			return
+		}
+		b.WriteString(strconv.Itoa(e.w.size))
+	},
+	"B": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(strconv.Itoa(e.w.size))
+	},
+	"D": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(strconv.FormatInt(e.duration.Microseconds(), 10))
+	},
+	"T": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(strconv.FormatInt(int64(e.duration.Seconds()), 10))
+	},
+	"m": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(e.r.Method)
+	},
+	"U": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(e.r.URL.EscapedPath())
+	},
+	"q": func(b *strings.Builder, e *accessLogEntry) {
+		if uri := redactUrl(e.r.URL); strings.Contains(uri, "?") {
+			b.WriteString(uri[strings.IndexByte(uri, '?'):])
+		}
+	},
+	"H": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString(e.r.Proto)
+	},
+	"%": func(b *strings.Builder, e *accessLogEntry) {
+		b.WriteString("%")
+	},
+}
+
+// dashIfEmpty returns "-" if the string is empty.
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// accessLogHeader returns the escaped value of the header, "-" if it is
+// empty, or a redacted placeholder if the header is sensitive.
+func accessLogHeader(h http.Header, name string) string {
+	value := h.Get(name)
+	if value != "" && isSensitive(name) {
+		return redacted
+	}
+	return dashIfEmpty(escapeAccessLog(value))
+}
+
+// escapeAccessLog escapes quotes, backslashes and non-printable bytes
+// of a client-controlled value, like "\"", "\n" and "\xNN".
+func escapeAccessLog(s string) string {
+	const hex = "0123456789abcdef"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c < 0x20 || c >= 0x7f:
+			b.Write([]byte{'\\', 'x', hex[c>>4], hex[c&0xf]})
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
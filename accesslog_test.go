@@ -0,0 +1,76 @@
+package rio
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// AccessLog
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestAccessLog(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Cache", "HIT")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	})
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("POST", "/items?token=abc", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		r.SetBasicAuth("alice", "secret")
+		r.Header.Set("Referer", "https://example.com/")
+		r.Header.Set("User-Agent", "go-test")
+		return r
+	}
+
+	t.Run("CombinedLogFormat", func(t *testing.T) {
+		var buf bytes.Buffer
+		AccessLog(&buf, CombinedLogFormat)(handler).ServeHTTP(httptest.NewRecorder(), newRequest())
+
+		want := regexp.MustCompile(`^10\.0\.0\.1 - alice \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [-+]\d{4}\] ` +
+			`"POST /items\?token=%5BREDACTED%5D HTTP/1\.1" 201 5 "https://example\.com/" "go-test"\n$`)
+		assert(t, want.MatchString(buf.String()), true)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		var buf bytes.Buffer
+		AccessLog(&buf, `%m %U %q %s %B %{X-Cache}o %{X-Missing}i 100%% %z`)(handler).ServeHTTP(httptest.NewRecorder(), newRequest())
+
+		assert(t, buf.String(), "POST /items ?token=%5BREDACTED%5D 201 5 HIT - 100% %z\n")
+	})
+	t.Run("escape", func(t *testing.T) {
+		r := newRequest()
+		r.SetBasicAuth("eve\n10.0.0.2 - admin", "x")
+		r.Header.Set("User-Agent", "a\"b\\c\x00\xff")
+
+		var buf bytes.Buffer
+		AccessLog(&buf, `%u "%{User-Agent}i"`)(handler).ServeHTTP(httptest.NewRecorder(), r)
+
+		assert(t, buf.String(), `eve\n10.0.0.2 - admin "a\"b\\c\x00\xff"`+"\n")
+	})
+
+	t.Run("redact-headers", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Set-Cookie", "session=abc")
+		})
+		r := newRequest()
+
+		var buf bytes.Buffer
+		AccessLog(&buf, `%{Authorization}i %{Set-Cookie}o %{Cookie}i`)(handler).ServeHTTP(httptest.NewRecorder(), r)
+
+		assert(t, buf.String(), "[REDACTED] [REDACTED] -\n")
+	})
+}
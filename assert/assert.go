@@ -0,0 +1,201 @@
+// Package assert implements helpers to check values, errors and
+// http responses in tests, for rio and the apps which use it.
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Equal checks that value a is equal to expected.
+func Equal(t testing.TB, got, want any) {
+	t.Helper()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %#v, got %#v", want, got)
+	}
+}
+
+// Panic checks if the given function f panics.
+func Panic(t testing.TB, f func()) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("the code did not panic")
+		}
+	}()
+	f() // function that panics
+}
+
+// Nil checks that the value is nil, including typed nils like a nil *T in an interface.
+func Nil(t testing.TB, got any) {
+	t.Helper()
+	if !isNil(got) {
+		t.Errorf("want nil, got %#v", got)
+	}
+}
+
+// NotNil checks that the value is not nil.
+func NotNil(t testing.TB, got any) {
+	t.Helper()
+	if isNil(got) {
+		t.Error("want not nil, got nil")
+	}
+}
+
+// isNil checks if the value is nil, or a nil chan, func, map, pointer, interface or slice.
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.Interface, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// Contains checks that a string contains the substring, that a slice
+// or array contains the element, or that a map contains the key.
+func Contains(t testing.TB, got, want any) {
+	t.Helper()
+	if !contains(got, want) {
+		t.Errorf("want %#v to contain %#v", got, want)
+	}
+}
+
+// contains checks if the container contains the value.
+func contains(container, v any) bool {
+	if s, ok := container.(string); ok {
+		sub, ok := v.(string)
+		return ok && strings.Contains(s, sub)
+	}
+
+	rv := reflect.ValueOf(container)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			if reflect.DeepEqual(rv.Index(i).Interface(), v) {
+				return true
+			}
+		}
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			if reflect.DeepEqual(key.Interface(), v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Len checks the length of a string, slice, array, map or chan.
+func Len(t testing.TB, got any, want int) {
+	t.Helper()
+
+	rv := reflect.ValueOf(got)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		if rv.Len() != want {
+			t.Errorf("want length %d, got %d", want, rv.Len())
+		}
+	default:
+		t.Errorf("cannot get the length of %#v", got)
+	}
+}
+
+// ErrorIs checks that errors.Is(err, target) is true.
+func ErrorIs(t testing.TB, err, target error) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("want error %v, got %v", target, err)
+	}
+}
+
+// ErrorAs checks that errors.As(err, target) is true, and sets target.
+func ErrorAs(t testing.TB, err error, target any) {
+	t.Helper()
+	if err == nil || !errors.As(err, target) {
+		t.Errorf("want error of type %T, got %v", target, err)
+	}
+}
+
+// Eventually checks that the condition is true within the timeout,
+// checking it every tick. It is useful for asynchronous code.
+func Eventually(t testing.TB, cond func() bool, timeout, tick time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if cond() {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Errorf("condition not met within %v", timeout)
+			return
+		}
+		time.Sleep(tick)
+	}
+}
+
+// ------------------------------------------------------------------
+//
+//
+// HTTP Assertions
+//
+//
+// ------------------------------------------------------------------
+
+// Status checks the status code of the recorded response.
+func Status(t testing.TB, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Errorf("want status %d, got %d: %s", want, rec.Code, rec.Body.String())
+	}
+}
+
+// Header checks the value of a header of the recorded response.
+func Header(t testing.TB, rec *httptest.ResponseRecorder, key, want string) {
+	t.Helper()
+	if got := rec.Header().Get(key); got != want {
+		t.Errorf("want header %s %q, got %q", key, want, got)
+	}
+}
+
+// JsonBody checks that the recorded response body is json equal to want.
+// The body and want are compared after decoding, so that formatting
+// and key order are ignored. The want can be a value or a json string.
+func JsonBody(t testing.TB, rec *httptest.ResponseRecorder, want any) {
+	t.Helper()
+
+	var got any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Errorf("cannot decode json body %q: %v", rec.Body.String(), err)
+		return
+	}
+
+	b, ok := want.(string)
+	if !ok {
+		encoded, err := json.Marshal(want)
+		if err != nil {
+			t.Errorf("cannot encode want %#v: %v", want, err)
+			return
+		}
+		b = string(encoded)
+	}
+
+	var wantJson any
+	if err := json.Unmarshal([]byte(b), &wantJson); err != nil {
+		t.Errorf("cannot decode want %q: %v", b, err)
+		return
+	}
+
+	if !reflect.DeepEqual(got, wantJson) {
+		t.Errorf("want json body %s, got %s", b, strings.TrimSpace(rec.Body.String()))
+	}
+}
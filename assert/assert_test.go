@@ -0,0 +1,119 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeT records whether an assertion failed, instead of failing the test.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (t *fakeT) Helper()               {}
+func (t *fakeT) Error(args ...any)     { t.failed = true }
+func (t *fakeT) Errorf(string, ...any) { t.failed = true }
+
+// check runs the assertion against a fakeT, and checks if it failed.
+func check(t *testing.T, wantFail bool, fn func(t testing.TB)) {
+	t.Helper()
+	ft := &fakeT{}
+	fn(ft)
+	if ft.failed != wantFail {
+		t.Errorf("want failed=%v, got failed=%v", wantFail, ft.failed)
+	}
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Assertions
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestAssertions(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var p *int
+		var err error
+		check(t, false, func(t testing.TB) { Nil(t, nil) })
+		check(t, false, func(t testing.TB) { Nil(t, p) })
+		check(t, false, func(t testing.TB) { Nil(t, err) })
+		check(t, true, func(t testing.TB) { Nil(t, 0) })
+		check(t, false, func(t testing.TB) { NotNil(t, "") })
+		check(t, true, func(t testing.TB) { NotNil(t, p) })
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		check(t, false, func(t testing.TB) { Contains(t, "hello world", "lo w") })
+		check(t, true, func(t testing.TB) { Contains(t, "hello", "bye") })
+		check(t, false, func(t testing.TB) { Contains(t, []int{1, 2}, 2) })
+		check(t, true, func(t testing.TB) { Contains(t, []int{1, 2}, 3) })
+		check(t, false, func(t testing.TB) { Contains(t, map[string]int{"a": 1}, "a") })
+		check(t, true, func(t testing.TB) { Contains(t, 5, 5) })
+	})
+
+	t.Run("Len", func(t *testing.T) {
+		check(t, false, func(t testing.TB) { Len(t, []int{1, 2}, 2) })
+		check(t, false, func(t testing.TB) { Len(t, "abc", 3) })
+		check(t, true, func(t testing.TB) { Len(t, map[int]int{}, 1) })
+		check(t, true, func(t testing.TB) { Len(t, 5, 0) })
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		err := fmt.Errorf("open: %w", fs.ErrNotExist)
+		check(t, false, func(t testing.TB) { ErrorIs(t, err, fs.ErrNotExist) })
+		check(t, true, func(t testing.TB) { ErrorIs(t, err, fs.ErrExist) })
+
+		var pathErr *fs.PathError
+		check(t, false, func(t testing.TB) { ErrorAs(t, &fs.PathError{Err: err}, &pathErr) })
+		check(t, true, func(t testing.TB) { ErrorAs(t, errors.New("x"), &pathErr) })
+		check(t, true, func(t testing.TB) { ErrorAs(t, nil, &pathErr) })
+	})
+
+	t.Run("Eventually", func(t *testing.T) {
+		n := 0
+		check(t, false, func(t testing.TB) {
+			Eventually(t, func() bool { n++; return n == 3 }, time.Second, time.Millisecond)
+		})
+		check(t, true, func(t testing.TB) {
+			Eventually(t, func() bool { return false }, 5*time.Millisecond, time.Millisecond)
+		})
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// HTTP Assertions
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestHttpAssertions(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	rec.WriteHeader(201)
+	rec.WriteString(`{"b": [1, 2], "a": "x"}`)
+
+	check(t, false, func(t testing.TB) { Status(t, rec, 201) })
+	check(t, true, func(t testing.TB) { Status(t, rec, 200) })
+	check(t, false, func(t testing.TB) { Header(t, rec, "Content-Type", "application/json") })
+	check(t, true, func(t testing.TB) { Header(t, rec, "Content-Type", "text/html") })
+	check(t, false, func(t testing.TB) { JsonBody(t, rec, `{"a":"x","b":[1,2]}`) })
+	check(t, false, func(t testing.TB) { JsonBody(t, rec, map[string]any{"a": "x", "b": []int{1, 2}}) })
+	check(t, true, func(t testing.TB) { JsonBody(t, rec, `{"a":"y"}`) })
+}
@@ -0,0 +1,97 @@
+package rio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Assets
+//
+//
+// ------------------------------------------------------------------
+
+// Assets builds cache-busting urls for static files.
+//
+// Each url has a version query param derived from a hash of the file's
+// contents, so the files can be cached indefinitely by browsers.
+//
+//	assets := NewAssets(staticFS, "/static/")
+//	assets.Url("css/app.css") // "/static/css/app.css?v=3b1f9a0c"
+//
+// .
+type Assets struct {
+	fsys   fs.FS
+	prefix string
+
+	mu     sync.Mutex
+	hashes map[string]string
+}
+
+// NewAssets constructs and returns a new *Assets.
+//
+// The fsys contains the static files, and the prefix is
+// the url path which the files are served from.
+func NewAssets(fsys fs.FS, prefix string) *Assets {
+	return &Assets{
+		fsys:   fsys,
+		prefix: prefix,
+		hashes: make(map[string]string),
+	}
+}
+
+// Url returns the url of the named file, with a version query param.
+// If the file does not exist, then the url has no version.
+func (a *Assets) Url(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	url := path.Join(a.prefix, name)
+
+	if hash := a.hash(name); hash != "" {
+		return url + "?v=" + hash
+	}
+	return url
+}
+
+// hash returns the short content hash of the named file. Hashes are cached.
+func (a *Assets) hash(name string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if hash, ok := a.hashes[name]; ok {
+		return hash
+	}
+
+	data, err := fs.ReadFile(a.fsys, name)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:4])
+	a.hashes[name] = hash
+	return hash
+}
+
+// WithAssets sets the "asset" template function, which returns
+// the cache-busting url of a static file.
+//
+//	<link rel="stylesheet" href="{{ asset "css/app.css" }}">
+//
+// .
+func WithAssets(a *Assets) ViewOpt {
+	return func(v *View) {
+		v.funcMap["asset"] = a.Url
+	}
+}
+
+// assetPath is the default "asset" template function.
+// It returns the path unchanged.
+func assetPath(name string) string {
+	return name
+}
@@ -0,0 +1,36 @@
+package rio
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Assets
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestAssets(t *testing.T) {
+	assets := NewAssets(fstest.MapFS{
+		"css/app.css": {Data: []byte("body{}")},
+	}, "/static/")
+
+	assert(t, assets.Url("css/app.css"), "/static/css/app.css?v=7c98040a")
+	assert(t, assets.Url("/css/app.css"), "/static/css/app.css?v=7c98040a")
+	assert(t, assets.Url("js/missing.js"), "/static/js/missing.js")
+
+	view := NewView(fstest.MapFS{
+		"page.html": {Data: []byte(`{{ asset "css/app.css" }}`)},
+	}, WithAssets(assets))
+
+	str, err := view.RenderToString("page.html", nil)
+	assert(t, err, nil)
+	assert(t, str, "/static/css/app.css?v=7c98040a")
+}
@@ -0,0 +1,90 @@
+package rio
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/tunedmystic/rio/forms"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Form Binding
+//
+//
+// ------------------------------------------------------------------
+
+// maxMemory is the memory limit when parsing multipart form data.
+const maxMemory = 32 << 20
+
+// BindForm parses the request's form data, and passes the values to the
+// build func to clean them into a *forms.Form.
+//
+// If the request's form data cannot be parsed, then a 400 Bad Request
+// AppError is returned.
+//
+//	form, err := BindForm(r, func(f *forms.Form, v url.Values) {
+//		f.CleanString("name", v.Get("name"), forms.StrRequired())
+//		f.CleanInteger("age", v.Get("age"), forms.IntGte(18))
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if !form.IsValid() {
+//		return FormErrorsJson(w, form)
+//	}
+//
+// .
+func BindForm(r *http.Request, build func(*forms.Form, url.Values)) (*forms.Form, error) {
+	if err := parseForm(r); err != nil {
+		return nil, HttpError("invalid form data", http.StatusBadRequest)
+	}
+
+	form := forms.New()
+	build(form, r.Form)
+	return form, nil
+}
+
+// parseForm parses the url-encoded or multipart form data of the request.
+func parseForm(r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+
+	err := r.ParseMultipartForm(maxMemory)
+	if errors.Is(err, http.ErrNotMultipart) {
+		return nil
+	}
+	return err
+}
+
+// formErrors is the json representation of a form's errors.
+type formErrors struct {
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Errors  []string          `json:"errors,omitempty"`
+}
+
+// FormErrorsJson writes the form's errors as json with a 422 status.
+//
+// Field errors are keyed by the field name, and non-field errors
+// are collected in a list.
+func FormErrorsJson(w http.ResponseWriter, form *forms.Form) error {
+	status := http.StatusUnprocessableEntity
+	errs := form.ErrorsJson()
+	data := formErrors{
+		Message: http.StatusText(status),
+		Fields:  errs.Fields,
+		Errors:  errs.NonField,
+	}
+
+	return JsonStatus(w, status, data)
+}
+
+// FormErrorsHtml re-renders the page with a 422 status, using the default View.
+// The data is typically the page's data, including the invalid form.
+func FormErrorsHtml(w http.ResponseWriter, page string, data any) error {
+	return Render(w, page, http.StatusUnprocessableEntity, data)
+}
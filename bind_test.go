@@ -0,0 +1,63 @@
+package rio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/tunedmystic/rio/forms"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Form Binding
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestBindForm(t *testing.T) {
+	build := func(f *forms.Form, v url.Values) {
+		f.CleanString("name", v.Get("name"), forms.StrRequired())
+		f.CleanInteger("age", v.Get("age"), forms.IntGte(18))
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", strings.NewReader("name=alice&age=30"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		form, err := BindForm(r, build)
+
+		assert(t, err, nil)
+		assert(t, form.IsValid(), true)
+		assert(t, form.CleanedString("name"), "alice")
+		assert(t, form.CleanedInteger("age"), 30)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/", strings.NewReader("age=12"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		form, err := BindForm(r, build)
+		assert(t, err, nil)
+		assert(t, form.IsValid(), false)
+
+		w := httptest.NewRecorder()
+		assert(t, FormErrorsJson(w, form), nil)
+		assert(t, w.Code, http.StatusUnprocessableEntity)
+		assert(t, w.Body.String(), `{"message":"Unprocessable Entity","fields":{"age":"must be more than or equal to 18","name":"cannot be blank"}}`)
+	})
+
+	t.Run("bad-form-data", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/?a=%zz", nil)
+
+		_, err := BindForm(r, build)
+		assert(t, err, HttpError("invalid form data", http.StatusBadRequest))
+	})
+}
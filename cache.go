@@ -0,0 +1,183 @@
+package rio
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: CachePolicy
+//
+//
+// ------------------------------------------------------------------
+
+// CachePolicy describes the Cache-Control directives of a response.
+//
+//	SetCacheHeaders(w, CachePolicy{
+//		Public:               true,
+//		MaxAge:               time.Hour,
+//		StaleWhileRevalidate: time.Minute,
+//	})
+//
+// .
+type CachePolicy struct {
+	Public               bool
+	Private              bool
+	NoCache              bool
+	NoStore              bool
+	MaxAge               time.Duration
+	SMaxAge              time.Duration
+	StaleWhileRevalidate time.Duration
+	Immutable            bool
+}
+
+// String returns the policy as a Cache-Control header value.
+//
+// If NoStore is set, then all other directives are ignored.
+func (p CachePolicy) String() string {
+	if p.NoStore {
+		return "no-store"
+	}
+
+	var parts []string
+	if p.Public {
+		parts = append(parts, "public")
+	}
+	if p.Private {
+		parts = append(parts, "private")
+	}
+	if p.NoCache {
+		parts = append(parts, "no-cache")
+	}
+	if p.MaxAge > 0 {
+		parts = append(parts, "max-age="+seconds(p.MaxAge))
+	}
+	if p.SMaxAge > 0 {
+		parts = append(parts, "s-maxage="+seconds(p.SMaxAge))
+	}
+	if p.StaleWhileRevalidate > 0 {
+		parts = append(parts, "stale-while-revalidate="+seconds(p.StaleWhileRevalidate))
+	}
+	if p.Immutable {
+		parts = append(parts, "immutable")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// seconds formats a duration as a whole number of seconds.
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Cache Header Helpers
+//
+//
+// ------------------------------------------------------------------
+
+// SetCacheHeaders sets the Cache-Control header from the given policy.
+func SetCacheHeaders(w http.ResponseWriter, p CachePolicy) {
+	if val := p.String(); val != "" {
+		w.Header().Set("Cache-Control", val)
+	}
+}
+
+// SetLastModified sets the Last-Modified header to the given time.
+func SetLastModified(w http.ResponseWriter, t time.Time) {
+	w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+}
+
+// SetETag sets the ETag header to the given tag.
+//
+// The tag is quoted if it is not already. A weak tag
+// can be provided with the "W/" prefix.
+func SetETag(w http.ResponseWriter, tag string) {
+	if !strings.HasPrefix(tag, `"`) && !strings.HasPrefix(tag, `W/"`) {
+		tag = fmt.Sprintf("%q", tag)
+	}
+	w.Header().Set("ETag", tag)
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Conditional Requests
+//
+//
+// ------------------------------------------------------------------
+
+// NotModified evaluates the request's If-None-Match and If-Modified-Since
+// headers against the given validators.
+//
+// The ETag and Last-Modified headers are set, if the validators are provided.
+// If the client's cached copy is still fresh, then a 304 Not Modified is
+// written and true is returned. Only GET and HEAD requests are evaluated.
+//
+//	if NotModified(w, r, post.Version, post.UpdatedAt) {
+//		return nil
+//	}
+//	return Render(w, "post.html", http.StatusOK, post)
+//
+// .
+func NotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	if etag != "" {
+		SetETag(w, etag)
+		etag = w.Header().Get("ETag")
+	}
+	if !lastModified.IsZero() {
+		SetLastModified(w, lastModified)
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if !isFresh(r, etag, lastModified) {
+		return false
+	}
+
+	h := w.Header()
+	h.Del("Content-Type")
+	h.Del("Content-Length")
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// isFresh checks if the request's conditional headers match the validators.
+// If-None-Match takes precedence over If-Modified-Since.
+func isFresh(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" {
+			return false
+		}
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || weakTag(tag) == weakTag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(t)
+	}
+
+	return false
+}
+
+// weakTag strips the weak prefix of an entity tag, for weak comparison.
+func weakTag(tag string) string {
+	return strings.TrimPrefix(tag, "W/")
+}
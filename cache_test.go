@@ -0,0 +1,87 @@
+package rio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Cache Headers
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestCacheHeaders(t *testing.T) {
+	t.Run("CachePolicy", func(t *testing.T) {
+		p := CachePolicy{
+			Public:               true,
+			MaxAge:               time.Hour,
+			SMaxAge:              2 * time.Hour,
+			StaleWhileRevalidate: time.Minute,
+			Immutable:            true,
+		}
+		assert(t, p.String(), "public, max-age=3600, s-maxage=7200, stale-while-revalidate=60, immutable")
+		assert(t, CachePolicy{NoStore: true, MaxAge: time.Hour}.String(), "no-store")
+	})
+
+	t.Run("setters", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		SetCacheHeaders(w, CachePolicy{Private: true, NoCache: true})
+		SetLastModified(w, time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC))
+		SetETag(w, "abc")
+
+		assert(t, w.Header().Get("Cache-Control"), "private, no-cache")
+		assert(t, w.Header().Get("Last-Modified"), "Wed, 10 Jan 2024 12:00:00 GMT")
+		assert(t, w.Header().Get("ETag"), `"abc"`)
+
+		SetETag(w, `W/"abc"`)
+		assert(t, w.Header().Get("ETag"), `W/"abc"`)
+	})
+}
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		value  string
+		want   bool
+	}{
+		{"no-conditions", "GET", "", "", false},
+		{"etag-match", "GET", "If-None-Match", `"v1"`, true},
+		{"etag-weak-match", "GET", "If-None-Match", `"v0", W/"v1"`, true},
+		{"etag-star", "GET", "If-None-Match", `*`, true},
+		{"etag-mismatch", "GET", "If-None-Match", `"v2"`, false},
+		{"modified-since-fresh", "GET", "If-Modified-Since", "Wed, 10 Jan 2024 12:00:00 GMT", true},
+		{"modified-since-stale", "GET", "If-Modified-Since", "Wed, 10 Jan 2024 11:00:00 GMT", false},
+		{"post-ignored", "POST", "If-None-Match", `"v1"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+
+			got := NotModified(w, r, "v1", modified)
+
+			assert(t, got, tt.want)
+			assert(t, w.Header().Get("ETag"), `"v1"`)
+			if got {
+				assert(t, w.Code, http.StatusNotModified)
+			}
+		})
+	}
+}
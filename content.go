@@ -0,0 +1,50 @@
+package rio
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Content Responses
+//
+//
+// ------------------------------------------------------------------
+
+// ServeContent writes the content to the ResponseWriter, honoring Range requests.
+//
+// The name is used to detect the Content-Type, if it is not already set.
+// If modtime is not the zero time, then it is used for the Last-Modified header
+// and for conditional requests.
+//
+// Range requests let clients resume downloads of large dynamic content,
+// like generated exports or media proxied from storage.
+//
+//	f, _ := os.Open(exportPath)
+//	defer f.Close()
+//	ServeContent(w, r, "export.csv", info.ModTime(), f)
+//
+// .
+func ServeContent(w http.ResponseWriter, r *http.Request, name string, modtime time.Time, content io.ReadSeeker) {
+	http.ServeContent(w, r, name, modtime, content)
+}
+
+// ServeReaderAt writes size bytes of the content to the ResponseWriter,
+// honoring Range requests.
+//
+// This is useful for sources which support random access but not seeking,
+// like an object in remote storage.
+func ServeReaderAt(w http.ResponseWriter, r *http.Request, name string, modtime time.Time, content io.ReaderAt, size int64) {
+	ServeContent(w, r, name, modtime, io.NewSectionReader(content, 0, size))
+}
+
+// ServeAttachment writes the content as a file download, honoring Range requests.
+// The filename is suggested to the client in the Content-Disposition header.
+func ServeAttachment(w http.ResponseWriter, r *http.Request, filename string, modtime time.Time, content io.ReadSeeker) {
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	ServeContent(w, r, filename, modtime, content)
+}
@@ -0,0 +1,364 @@
+// Package decimal implements an exact decimal number type,
+// for money and other values which cannot lose precision.
+package decimal
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Decimal
+//
+//
+// ------------------------------------------------------------------
+
+// ErrDivisionByZero is returned when dividing a Decimal by zero.
+var ErrDivisionByZero = errors.New("decimal: division by zero")
+
+// maxPlaces is the number of decimal places of the String of
+// a non-terminating Decimal, like 1/3.
+const maxPlaces = 16
+
+// Decimal is an exact decimal number, backed by a big.Rat.
+//
+// A Decimal is immutable, and the zero value is 0. Arithmetic is exact,
+// so that 0.1 + 0.2 == 0.3, and rounding only happens when asked for.
+//
+//	price := decimal.MustParse("19.99")
+//	total := price.Mul(decimal.NewFromInt(3)).Round(2, decimal.RoundHalfEven)
+//	total.FormatString(2, true) // "59.97"
+//
+// .
+type Decimal struct {
+	rat *big.Rat
+}
+
+// NewFromInt returns the Decimal of an integer.
+func NewFromInt(n int64) Decimal {
+	return Decimal{new(big.Rat).SetInt64(n)}
+}
+
+// NewFromFloat returns the Decimal of the shortest decimal representation
+// of a float64, so that 0.1 is exactly 0.1. NaN and infinities are an error.
+func NewFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("decimal: cannot convert %v", f)
+	}
+	return Parse(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// NewFromRat returns the Decimal of a big.Rat. The big.Rat is copied.
+func NewFromRat(r *big.Rat) Decimal {
+	return Decimal{new(big.Rat).Set(r)}
+}
+
+// Parse parses a decimal string, like "12.50", "-3" or "1e3".
+// Fractions, like "1/3", are also accepted.
+func Parse(s string) (Decimal, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Decimal{}, fmt.Errorf("decimal: cannot parse %q", s)
+	}
+	return Decimal{r}, nil
+}
+
+// MustParse is like Parse, but panics if the string cannot be parsed.
+// It is intended for constants, like MustParse("0.08").
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Rat returns a copy of the Decimal as a big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).Set(d.value())
+}
+
+// value returns the underlying big.Rat, which must not be modified.
+func (d Decimal) value() *big.Rat {
+	if d.rat == nil {
+		return new(big.Rat)
+	}
+	return d.rat
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Arithmetic
+//
+//
+// ------------------------------------------------------------------
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	return Decimal{new(big.Rat).Add(d.value(), e.value())}
+}
+
+// Sub returns d - e.
+func (d Decimal) Sub(e Decimal) Decimal {
+	return Decimal{new(big.Rat).Sub(d.value(), e.value())}
+}
+
+// Mul returns d * e.
+func (d Decimal) Mul(e Decimal) Decimal {
+	return Decimal{new(big.Rat).Mul(d.value(), e.value())}
+}
+
+// Div returns d / e. It returns ErrDivisionByZero if e is zero.
+// The result is exact, so that 1 / 3 * 3 == 1.
+func (d Decimal) Div(e Decimal) (Decimal, error) {
+	if e.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	return Decimal{new(big.Rat).Quo(d.value(), e.value())}, nil
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{new(big.Rat).Neg(d.value())}
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal) Abs() Decimal {
+	return Decimal{new(big.Rat).Abs(d.value())}
+}
+
+// Cmp compares d and e, and returns -1 if d < e, 0 if d == e, and 1 if d > e.
+func (d Decimal) Cmp(e Decimal) int {
+	return d.value().Cmp(e.value())
+}
+
+// Equal checks if d == e.
+func (d Decimal) Equal(e Decimal) bool {
+	return d.Cmp(e) == 0
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0, and 1 if d > 0.
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+// IsZero checks if d == 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Float64 returns the nearest float64 of d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.value().Float64()
+	return f
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Rounding
+//
+//
+// ------------------------------------------------------------------
+
+// RoundingMode is the way a Decimal is rounded to a number of places.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest, and ties away from zero, like 2.5 => 3.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest, and ties to even, like 2.5 => 2.
+	RoundHalfEven
+	// RoundDown rounds toward zero, like -2.7 => -2.
+	RoundDown
+	// RoundUp rounds away from zero, like -2.1 => -3.
+	RoundUp
+	// RoundFloor rounds toward negative infinity, like -2.1 => -3.
+	RoundFloor
+	// RoundCeiling rounds toward positive infinity, like -2.7 => -2.
+	RoundCeiling
+)
+
+// Round returns d rounded to the number of decimal places, with the rounding mode.
+// Negative places are treated as 0.
+func (d Decimal) Round(places int, mode RoundingMode) Decimal {
+	places = max(places, 0)
+	r := d.value()
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+
+	// Truncate d * scale toward zero, keeping the remainder.
+	num := new(big.Int).Mul(r.Num(), scale)
+	q, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+
+	if rem.Sign() != 0 {
+		sign := big.NewInt(int64(r.Sign()))
+		twice := new(big.Int).Abs(rem)
+		half := twice.Lsh(twice, 1).Cmp(r.Denom())
+
+		var away bool
+		switch mode {
+		case RoundHalfUp:
+			away = half >= 0
+		case RoundHalfEven:
+			away = half > 0 || (half == 0 && q.Bit(0) == 1)
+		case RoundUp:
+			away = true
+		case RoundFloor:
+			away = r.Sign() < 0
+		case RoundCeiling:
+			away = r.Sign() > 0
+		}
+		if away {
+			q.Add(q, sign)
+		}
+	}
+
+	return Decimal{new(big.Rat).SetFrac(q, scale)}
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Formatting
+//
+//
+// ------------------------------------------------------------------
+
+// String returns the exact decimal string of d, like "12.5".
+// A non-terminating Decimal, like 1/3, is rounded to 16 places.
+func (d Decimal) String() string {
+	r := d.value()
+	places, ok := exactPlaces(r.Denom())
+	if !ok {
+		places = maxPlaces
+		s := d.Round(places, RoundHalfEven).value().FloatString(places)
+		return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return r.FloatString(places)
+}
+
+// exactPlaces returns the number of decimal places of a fraction with
+// the denominator, or false if its decimal representation does not terminate.
+func exactPlaces(denom *big.Int) (int, bool) {
+	n := new(big.Int).Set(denom)
+	two, five, mod := big.NewInt(2), big.NewInt(5), new(big.Int)
+
+	count := func(p *big.Int) int {
+		i := 0
+		for {
+			q, m := new(big.Int).QuoRem(n, p, mod)
+			if m.Sign() != 0 {
+				return i
+			}
+			n = q
+			i++
+		}
+	}
+
+	places := max(count(two), count(five))
+	return places, n.Cmp(big.NewInt(1)) == 0
+}
+
+// FormatString returns d rounded half up to the number of places, with
+// trailing zeros, and optionally grouped by thousands, like "12,345.50".
+func (d Decimal) FormatString(places int, grouping bool) string {
+	places = max(places, 0)
+	s := d.Round(places, RoundHalfUp).value().FloatString(places)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intStr, fracStr, _ := strings.Cut(s, ".")
+
+	if grouping && len(intStr) > 3 {
+		var b strings.Builder
+		for i, c := range intStr {
+			if i > 0 && (len(intStr)-i)%3 == 0 {
+				b.WriteByte(',')
+			}
+			b.WriteRune(c)
+		}
+		intStr = b.String()
+	}
+
+	if places == 0 {
+		return sign + intStr
+	}
+	return sign + intStr + "." + fracStr
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Marshaling
+//
+//
+// ------------------------------------------------------------------
+
+// MarshalJSON encodes d as a json string, like "12.5", so that
+// json decoders do not lose precision.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes d from a json string or number. A json null is zero.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Value implements driver.Valuer, and stores d as a decimal string.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner, and reads d from a decimal string or number.
+// A NULL is zero.
+func (d *Decimal) Scan(src any) error {
+	var (
+		v   Decimal
+		err error
+	)
+
+	switch src := src.(type) {
+	case nil:
+	case string:
+		v, err = Parse(src)
+	case []byte:
+		v, err = Parse(string(src))
+	case int64:
+		v = NewFromInt(src)
+	case float64:
+		v, err = NewFromFloat(src)
+	default:
+		err = fmt.Errorf("decimal: cannot scan %T", src)
+	}
+
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
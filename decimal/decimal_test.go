@@ -0,0 +1,186 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Type: Decimal
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestDecimal(t *testing.T) {
+	t.Run("constructors", func(t *testing.T) {
+		var zero Decimal
+		assert.Equal(t, zero.String(), "0")
+		assert.Equal(t, zero.IsZero(), true)
+
+		assert.Equal(t, NewFromInt(-42).String(), "-42")
+		assert.Equal(t, NewFromRat(big.NewRat(5, 4)).String(), "1.25")
+
+		d, err := NewFromFloat(0.1)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, d.String(), "0.1")
+
+		d, err = Parse("1e3")
+		assert.Equal(t, err, nil)
+		assert.Equal(t, d.String(), "1000")
+
+		_, err = Parse("bad")
+		assert.Equal(t, err != nil, true)
+		assert.Panic(t, func() { MustParse("bad") })
+	})
+
+	t.Run("arithmetic", func(t *testing.T) {
+		a, b := MustParse("0.1"), MustParse("0.2")
+		assert.Equal(t, a.Add(b).Equal(MustParse("0.3")), true)
+		assert.Equal(t, a.Sub(b).String(), "-0.1")
+		assert.Equal(t, a.Mul(b).String(), "0.02")
+		assert.Equal(t, a.Neg().String(), "-0.1")
+		assert.Equal(t, a.Neg().Abs().String(), "0.1")
+		assert.Equal(t, a.Cmp(b), -1)
+		assert.Equal(t, b.Sign(), 1)
+		assert.Equal(t, b.Float64(), 0.2)
+
+		third, err := NewFromInt(1).Div(NewFromInt(3))
+		assert.Equal(t, err, nil)
+		assert.Equal(t, third.String(), "0.3333333333333333")
+		assert.Equal(t, third.Mul(NewFromInt(3)).String(), "1")
+
+		_, err = a.Div(Decimal{})
+		assert.Equal(t, err, ErrDivisionByZero)
+	})
+
+	t.Run("Rat is a copy", func(t *testing.T) {
+		d := MustParse("1.5")
+		d.Rat().SetInt64(7)
+		assert.Equal(t, d.String(), "1.5")
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Rounding
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		value string
+		mode  RoundingMode
+		want  string
+	}{
+		{"2.5", RoundHalfUp, "3"},
+		{"-2.5", RoundHalfUp, "-3"},
+		{"2.4", RoundHalfUp, "2"},
+		{"2.5", RoundHalfEven, "2"},
+		{"3.5", RoundHalfEven, "4"},
+		{"2.51", RoundHalfEven, "3"},
+		{"-2.7", RoundDown, "-2"},
+		{"-2.1", RoundUp, "-3"},
+		{"2.1", RoundUp, "3"},
+		{"-2.1", RoundFloor, "-3"},
+		{"2.9", RoundFloor, "2"},
+		{"-2.7", RoundCeiling, "-2"},
+		{"2.1", RoundCeiling, "3"},
+		{"7", RoundUp, "7"},
+	}
+
+	for _, tt := range tests {
+		got := MustParse(tt.value).Round(0, tt.mode).String()
+		assert.Equal(t, got, tt.want)
+	}
+
+	assert.Equal(t, MustParse("1.005").Round(2, RoundHalfUp).String(), "1.01")
+	assert.Equal(t, MustParse("1.015").Round(2, RoundHalfEven).String(), "1.02")
+	assert.Equal(t, MustParse("12.34").Round(-1, RoundHalfUp).String(), "12")
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Formatting
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestFormatString(t *testing.T) {
+	assert.Equal(t, MustParse("1234567.891").FormatString(2, true), "1,234,567.89")
+	assert.Equal(t, MustParse("1234567.891").FormatString(2, false), "1234567.89")
+	assert.Equal(t, MustParse("-1234.5").FormatString(2, true), "-1,234.50")
+	assert.Equal(t, MustParse("999.995").FormatString(2, true), "1,000.00")
+	assert.Equal(t, MustParse("123").FormatString(0, true), "123")
+	assert.Equal(t, MustParse("-0.001").FormatString(2, true), "0.00")
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Marshaling
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestMarshaling(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(map[string]Decimal{"total": MustParse("12.50")})
+		assert.Equal(t, err, nil)
+		assert.Equal(t, string(b), `{"total":"12.5"}`)
+
+		var v struct{ A, B, C Decimal }
+		err = json.Unmarshal([]byte(`{"A":"1.25","B":3.75,"C":null}`), &v)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, v.A.String(), "1.25")
+		assert.Equal(t, v.B.String(), "3.75")
+		assert.Equal(t, v.C.IsZero(), true)
+
+		err = json.Unmarshal([]byte(`{"A":"bad"}`), &v)
+		assert.Equal(t, err != nil, true)
+	})
+
+	t.Run("sql", func(t *testing.T) {
+		v, err := MustParse("9.99").Value()
+		assert.Equal(t, err, nil)
+		assert.Equal(t, v, driver.Value("9.99"))
+
+		var d Decimal
+		for src, want := range map[any]string{"1.5": "1.5", int64(7): "7", 0.25: "0.25"} {
+			assert.Equal(t, d.Scan(src), nil)
+			assert.Equal(t, d.String(), want)
+		}
+
+		assert.Equal(t, d.Scan([]byte("2.75")), nil)
+		assert.Equal(t, d.String(), "2.75")
+
+		assert.Equal(t, d.Scan(nil), nil)
+		assert.Equal(t, d.IsZero(), true)
+
+		assert.Equal(t, d.Scan(true) != nil, true)
+	})
+}
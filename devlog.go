@@ -0,0 +1,218 @@
+package rio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Dev Log Handler
+//
+//
+// ------------------------------------------------------------------
+
+// Terminal color codes.
+const (
+	colorReset  = "\033[0m"
+	colorDim    = "\033[2m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorCyan   = "\033[36m"
+)
+
+// devMessageWidth is the width the message is padded to, so that
+// the attributes of consecutive records line up.
+const devMessageWidth = 24
+
+// devHandler is a slog.Handler which writes human-friendly lines, like:
+//
+//	15:04:05.000 INF request                  status=200 method=GET url=/ time=1.2ms
+//
+// .
+type devHandler struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	level  slog.Leveler
+	color  bool
+	attrs  string
+	prefix string
+}
+
+// NewDevHandler constructs and returns a slog.Handler for local development.
+//
+// It writes colorized levels, aligned attributes and compact durations.
+// The opts can be nil. Only the level of the opts is used.
+func NewDevHandler(w io.Writer, opts *slog.HandlerOptions, color bool) slog.Handler {
+	h := &devHandler{
+		w:     w,
+		mu:    &sync.Mutex{},
+		level: slog.LevelInfo,
+		color: color,
+	}
+	if opts != nil && opts.Level != nil {
+		h.level = opts.Level
+	}
+	return h
+}
+
+// Enabled checks if the level is at or above the handler's level.
+func (h *devHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes the record as a single line.
+func (h *devHandler) Handle(ctx context.Context, r slog.Record) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	h.paint(buf, colorDim, r.Time.Format("15:04:05.000"))
+	buf.WriteByte(' ')
+	h.paint(buf, levelColor(r.Level), levelLabel(r.Level))
+	buf.WriteByte(' ')
+	fmt.Fprintf(buf, "%-*s", devMessageWidth, r.Message)
+	buf.WriteString(h.attrs)
+
+	r.Attrs(func(a slog.Attr) bool {
+		h.appendAttr(buf, h.prefix, a)
+		return true
+	})
+
+	// Trim the padding of records without attributes.
+	out := append(bytes.TrimRight(buf.Bytes(), " "), '\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(out)
+	return err
+}
+
+// WithAttrs returns a new devHandler, with the attributes pre-formatted.
+func (h *devHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var buf bytes.Buffer
+	for _, a := range attrs {
+		h.appendAttr(&buf, h.prefix, a)
+	}
+
+	h2 := *h
+	h2.attrs = h.attrs + buf.String()
+	return &h2
+}
+
+// WithGroup returns a new devHandler, which qualifies attribute keys with the group.
+func (h *devHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+// appendAttr writes the attribute as " key=value", flattening groups.
+func (h *devHandler) appendAttr(buf *bytes.Buffer, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			h.appendAttr(buf, prefix, ga)
+		}
+		return
+	}
+
+	buf.WriteByte(' ')
+	h.paint(buf, colorCyan, prefix+a.Key+"=")
+	buf.WriteString(devValue(a.Value))
+}
+
+// paint writes the text, wrapped in the color if colors are enabled.
+func (h *devHandler) paint(buf *bytes.Buffer, color, text string) {
+	if !h.color {
+		buf.WriteString(text)
+		return
+	}
+	buf.WriteString(color + text + colorReset)
+}
+
+// devValue formats an attribute value, compacting durations and quoting
+// strings which contain spaces.
+func devValue(v slog.Value) string {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return compactDuration(v.Duration())
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339)
+	}
+
+	s := v.String()
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// compactDuration rounds the duration to 3 significant digits, like 1.23ms.
+func compactDuration(d time.Duration) string {
+	switch abs := max(d, -d); {
+	case abs >= 100*time.Second:
+		return d.Round(time.Second).String()
+	case abs >= 10*time.Second:
+		return d.Round(100 * time.Millisecond).String()
+	case abs >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case abs >= 100*time.Millisecond:
+		return d.Round(time.Millisecond).String()
+	case abs >= 10*time.Millisecond:
+		return d.Round(100 * time.Microsecond).String()
+	case abs >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	case abs >= 100*time.Microsecond:
+		return d.Round(time.Microsecond).String()
+	case abs >= 10*time.Microsecond:
+		return d.Round(100 * time.Nanosecond).String()
+	case abs >= time.Microsecond:
+		return d.Round(10 * time.Nanosecond).String()
+	}
+	return d.String()
+}
+
+// levelLabel returns a 3 letter label of the level.
+func levelLabel(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERR"
+	case level >= slog.LevelWarn:
+		return "WRN"
+	case level >= slog.LevelInfo:
+		return "INF"
+	}
+	return "DBG"
+}
+
+// levelColor returns the color of the level.
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return colorRed
+	case level >= slog.LevelWarn:
+		return colorYellow
+	case level >= slog.LevelInfo:
+		return colorGreen
+	}
+	return colorDim
+}
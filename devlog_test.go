@@ -0,0 +1,58 @@
+package rio
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Dev Log Handler
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestDevHandler(t *testing.T) {
+	t.Run("format", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(NewDevHandler(&buf, nil, false)).With("app", "rio").WithGroup("req")
+
+		logger.Info("request",
+			slog.Int("status", 200),
+			slog.Duration("time", 1234567*time.Nanosecond),
+			slog.String("ua", "go test"),
+			slog.Any("err", errors.New("boom")),
+		)
+		logger.Debug("hidden")
+
+		// Strip the timestamp.
+		line := buf.String()[len("15:04:05.000 "):]
+		assert(t, line, `INF request                  app=rio req.status=200 req.time=1.23ms req.ua="go test" req.err=boom`+"\n")
+	})
+
+	t.Run("color", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(NewDevHandler(&buf, nil, true))
+
+		logger.Error("failed")
+
+		line := buf.String()[len(colorDim+"15:04:05.000"+colorReset+" "):]
+		assert(t, line, colorRed+"ERR"+colorReset+" failed\n")
+	})
+
+	t.Run("compactDuration", func(t *testing.T) {
+		assert(t, compactDuration(999*time.Nanosecond), "999ns")
+		assert(t, compactDuration(1234*time.Nanosecond), "1.23µs")
+		assert(t, compactDuration(123456*time.Microsecond), "123ms")
+		assert(t, compactDuration(12345*time.Millisecond), "12.3s")
+		assert(t, compactDuration(154*time.Second), "2m34s")
+	})
+}
@@ -20,10 +20,10 @@ func (a AppError) Error() string {
 // If the AppError is not Json, then a plain text message will be written.
 func (a AppError) WriteTo(w http.ResponseWriter) error {
 	if a.IsJson {
-		return writeJson(w, a.Message, a.Status)
+		return JsonStatus(w, a.Status, a.Message)
 	}
 
-	http.Error(w, a.Message, a.Status)
+	HttpStatus(w, a.Status, a.Message)
 	return nil
 }
 
@@ -44,3 +44,91 @@ func JsonError(message string, status int) AppError {
 		IsJson:  true,
 	}
 }
+
+// ------------------------------------------------------------------
+//
+//
+// Type: ErrorPages
+//
+//
+// ------------------------------------------------------------------
+
+// defaultErrorPages are the error pages used by the handlers and
+// middleware. If nil, then plain text errors are written.
+var defaultErrorPages *ErrorPages
+
+// UseErrorPages configures the error pages used by MakeHandler,
+// RecoverPanic and NotFound.
+//
+//	UseErrorPages(&ErrorPages{
+//		Pages: map[int]string{
+//			http.StatusForbidden:           "errors/403.html",
+//			http.StatusNotFound:            "errors/404.html",
+//			http.StatusInternalServerError: "errors/500.html",
+//		},
+//	})
+//
+// .
+func UseErrorPages(p *ErrorPages) {
+	defaultErrorPages = p
+}
+
+// ErrorPages renders html templates for error responses.
+//
+// The response format is negotiated with the request's Accept header,
+// so API clients still receive json or plain text errors.
+type ErrorPages struct {
+	// View renders the pages. Defaults to the default View.
+	View *View
+
+	// Pages maps a status code to a template name.
+	Pages map[int]string
+}
+
+// ErrorPageData is the data given to an error page template.
+type ErrorPageData struct {
+	Status  int
+	Title   string
+	Message string
+}
+
+// Write writes an error response with the given status and message.
+//
+// If there is no page for the status, then a plain text error is written.
+func (p *ErrorPages) Write(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	page, ok := p.Pages[status]
+	if !ok {
+		HttpStatus(w, status, msg)
+		return
+	}
+
+	Negotiate(w, r, Offers{
+		HTML: func() {
+			view := p.View
+			if view == nil {
+				view = defaultView
+			}
+
+			data := ErrorPageData{
+				Status:  status,
+				Title:   http.StatusText(status),
+				Message: msg,
+			}
+			if err := view.Render(w, page, status, data); err != nil {
+				LogError(err)
+				HttpStatus(w, status, msg)
+			}
+		},
+		JSON: func() { JsonStatus(w, status, msg) },
+		Text: func() { HttpStatus(w, status, msg) },
+	})
+}
+
+// writeError writes an error response, with the error pages if they are configured.
+func writeError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	if defaultErrorPages == nil {
+		HttpStatus(w, status, msg)
+		return
+	}
+	defaultErrorPages.Write(w, r, status, msg)
+}
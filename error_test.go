@@ -0,0 +1,68 @@
+package rio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// ErrorPages
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestErrorPages(t *testing.T) {
+	view := NewView(fstest.MapFS{
+		"404.html": {Data: []byte(`<h1>{{ .Status }} {{ .Title }}</h1>`)},
+	})
+	pages := &ErrorPages{
+		View:  view,
+		Pages: map[int]string{http.StatusNotFound: "404.html"},
+	}
+
+	UseErrorPages(pages)
+	defer UseErrorPages(nil)
+
+	t.Run("html", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/missing", nil)
+		r.Header.Set("Accept", "text/html")
+		w := httptest.NewRecorder()
+
+		NotFound(http.NotFoundHandler()).ServeHTTP(w, r)
+
+		assert(t, w.Code, http.StatusNotFound)
+		assert(t, w.Body.String(), "<h1>404 Not Found</h1>")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/missing", nil)
+		r.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+
+		NotFound(http.NotFoundHandler()).ServeHTTP(w, r)
+
+		assert(t, w.Code, http.StatusNotFound)
+		assert(t, w.Body.String(), `{"message":"Not Found"}`)
+	})
+
+	t.Run("no-page", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		w := httptest.NewRecorder()
+
+		h := MakeHandler(func(w http.ResponseWriter, r *http.Request) error {
+			return HttpError("forbidden", http.StatusForbidden)
+		})
+		h.ServeHTTP(w, r)
+
+		assert(t, w.Code, http.StatusForbidden)
+		assert(t, w.Body.String(), "forbidden\n")
+	})
+}
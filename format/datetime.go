@@ -1,7 +1,11 @@
 package format
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -50,12 +54,12 @@ func DateSlugIntl(d time.Time) string {
 
 // Today returns a time.Time equal to the current day (Time trimmed. Only year, month, day).
 func Today() time.Time {
-	return TrimTime(time.Now().UTC())
+	return TrimTime(Now())
 }
 
 // CurrentYear returns the current year as an int.
 func CurrentYear() int {
-	return time.Now().UTC().Year()
+	return Now().Year()
 }
 
 // TrimTime erases the hh:mm:ss:ns of the given time.Time.
@@ -63,6 +67,287 @@ func TrimTime(d time.Time) time.Time {
 	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
 }
 
+// ------------------------------------------------------------------
+//
+//
+// Clock
+//
+//
+// ------------------------------------------------------------------
+
+var (
+	clockMu  sync.RWMutex
+	clock    = time.Now
+	location = time.UTC
+)
+
+// Now returns the current time in the configured location, which defaults to UTC.
+// It is used by Today, CurrentYear, TimeAgo and TimeUntil, and by the
+// forms date checks.
+func Now() time.Time {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+	return clock().In(location)
+}
+
+// SetClock sets the func which returns the current time, which defaults to time.Now.
+// This is useful to freeze the time in tests. A nil func restores time.Now.
+//
+//	format.SetClock(func() time.Time { return fixed })
+//	defer format.SetClock(nil)
+//
+// .
+func SetClock(fn func() time.Time) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	if fn == nil {
+		fn = time.Now
+	}
+	clock = fn
+}
+
+// SetLocation sets the location of the current time, which defaults to UTC.
+// This makes Today return the current day of the users' timezone.
+// A nil location restores UTC.
+func SetLocation(loc *time.Location) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	location = loc
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Relative Time Formatters
+//
+//
+// ------------------------------------------------------------------
+
+// TimeAgo formats a past time.Time relative to now, like "3 hours ago".
+// A future time is formatted like TimeUntil.
+func TimeAgo(t time.Time) string {
+	return relativeTime(t.Sub(Now()))
+}
+
+// TimeUntil formats a future time.Time relative to now, like "in 3 hours".
+// A past time is formatted like TimeAgo.
+func TimeUntil(t time.Time) string {
+	return relativeTime(t.Sub(Now()))
+}
+
+// relativeUnits are the units of relative times, from the largest.
+var relativeUnits = []struct {
+	size time.Duration
+	name string
+}{
+	{365 * 24 * time.Hour, "year"},
+	{30 * 24 * time.Hour, "month"},
+	{7 * 24 * time.Hour, "week"},
+	{24 * time.Hour, "day"},
+	{time.Hour, "hour"},
+	{time.Minute, "minute"},
+}
+
+// relativeTime formats the offset from now in the largest whole unit.
+// A negative offset is in the past.
+func relativeTime(d time.Duration) string {
+	past := d < 0
+	if past {
+		d = -d
+	}
+
+	for _, unit := range relativeUnits {
+		if d < unit.size {
+			continue
+		}
+		n := int(d / unit.size)
+		s := fmt.Sprintf("%d %s", n, Plural(n, unit.name, unit.name+"s"))
+		if past {
+			return s + " ago"
+		}
+		return "in " + s
+	}
+
+	if past {
+		return "just now"
+	}
+	return "in less than a minute"
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Duration Formatters
+//
+//
+// ------------------------------------------------------------------
+
+// durationUnits are the units of human durations, from the largest.
+var durationUnits = []struct {
+	size time.Duration
+	name string
+}{
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+}
+
+// Duration formats a time.Duration to a string like "2h 15m" or "3d 4h",
+// with at most 2 units. Durations under a second are formatted as "0s".
+func Duration(d time.Duration) string {
+	return DurationPrecision(d, 2)
+}
+
+// DurationPrecision formats a time.Duration to a string like "3d 4h 12m",
+// with at most the given number of units. The remainder is truncated.
+func DurationPrecision(d time.Duration, units int) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	var parts []string
+	for _, unit := range durationUnits {
+		if len(parts) == units {
+			break
+		}
+		if n := d / unit.size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit.name))
+			d -= n * unit.size
+		} else if len(parts) > 0 {
+			// Keep the units adjacent, so "1d 0h" is shown as "1d".
+			break
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0s"
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// ParseDuration parses a human duration, like "2h 15m", "3d 4h" or "1d2h",
+// as formatted by Duration. Standard Go durations, like "1h30m", are also accepted.
+func ParseDuration(val string) (time.Duration, error) {
+	s := strings.TrimSpace(val)
+
+	sign := time.Duration(1)
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		sign, s = -1, rest
+	}
+
+	// A sign alone has no number and unit to parse.
+	parts := strings.Fields(s)
+	if len(parts) == 0 {
+		return 0, fmt.Errorf("invalid duration %q", val)
+	}
+
+	var total time.Duration
+	for _, part := range parts {
+		// Days are not supported by time.ParseDuration.
+		if days, rest, ok := strings.Cut(part, "d"); ok {
+			n, err := strconv.Atoi(days)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid duration %q", val)
+			}
+			total += time.Duration(n) * 24 * time.Hour
+			if part = rest; part == "" {
+				continue
+			}
+		}
+
+		d, err := time.ParseDuration(part)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("invalid duration %q", val)
+		}
+		total += d
+	}
+	return sign * total, nil
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Date Math
+//
+//
+// ------------------------------------------------------------------
+
+// StartOfWeek returns the start of the week (Monday) of the given time.Time.
+func StartOfWeek(d time.Time) time.Time {
+	offset := (int(d.Weekday()) + 6) % 7
+	return TrimTime(d).AddDate(0, 0, -offset)
+}
+
+// StartOfMonth returns the start of the month of the given time.Time.
+func StartOfMonth(d time.Time) time.Time {
+	return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())
+}
+
+// StartOfQuarter returns the start of the quarter of the given time.Time.
+func StartOfQuarter(d time.Time) time.Time {
+	month := time.Month((int(d.Month())-1)/3*3 + 1)
+	return time.Date(d.Year(), month, 1, 0, 0, 0, 0, d.Location())
+}
+
+// StartOfYear returns the start of the year of the given time.Time.
+func StartOfYear(d time.Time) time.Time {
+	return time.Date(d.Year(), time.January, 1, 0, 0, 0, 0, d.Location())
+}
+
+// EndOfWeek returns the last nanosecond of the week (Sunday) of the given time.Time.
+func EndOfWeek(d time.Time) time.Time {
+	return StartOfWeek(d).AddDate(0, 0, 7).Add(-time.Nanosecond)
+}
+
+// EndOfMonth returns the last nanosecond of the month of the given time.Time.
+func EndOfMonth(d time.Time) time.Time {
+	return StartOfMonth(d).AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+// EndOfQuarter returns the last nanosecond of the quarter of the given time.Time.
+func EndOfQuarter(d time.Time) time.Time {
+	return StartOfQuarter(d).AddDate(0, 3, 0).Add(-time.Nanosecond)
+}
+
+// EndOfYear returns the last nanosecond of the year of the given time.Time.
+func EndOfYear(d time.Time) time.Time {
+	return StartOfYear(d).AddDate(1, 0, 0).Add(-time.Nanosecond)
+}
+
+// AddBusinessDays adds n business days (Monday to Friday) to the given time.Time.
+// A negative n subtracts business days. Starting on a weekend, the first
+// business day counts as one day.
+func AddBusinessDays(d time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+
+	for n > 0 {
+		d = d.AddDate(0, 0, step)
+		if wd := d.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			n--
+		}
+	}
+	return d
+}
+
+// DaysBetween returns the number of calendar days from a to b,
+// which is negative if b is before a. The time of day is ignored.
+func DaysBetween(a, b time.Time) int {
+	start := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
 // ------------------------------------------------------------------
 //
 //
@@ -71,33 +356,118 @@ func TrimTime(d time.Time) time.Time {
 //
 // ------------------------------------------------------------------
 
-var dateFormats = []string{
-	"2006-01-02",      // Short
-	"January-2-2006",  // Slug
-	"2-January-2006",  // Slug (international)
-	"January 2, 2006", // Display
+var (
+	dateFormatsMu sync.RWMutex
+	dateFormats   = []string{
+		"2006-01-02",      // Short
+		"January-2-2006",  // Slug
+		"2-January-2006",  // Slug (international)
+		"January 2, 2006", // Display
+		time.RFC3339,      // Timestamp
+		"01/02/2006",      // US
+		"2 Jan 2006",      // Short display
+		"January 2006",    // Month and year
+		"Jan 2006",        // Short month and year
+		"2006-01",         // Short month and year
+	}
+)
+
+// RegisterDateLayout adds layouts to the list of layouts tried by ParseDate.
+// The layouts are tried after the built-in layouts, in the given order.
+//
+//	format.RegisterDateLayout("02.01.2006")
+//
+// .
+func RegisterDateLayout(layouts ...string) {
+	dateFormatsMu.Lock()
+	defer dateFormatsMu.Unlock()
+	dateFormats = append(dateFormats, layouts...)
+}
+
+// DateParseError is returned when a date does not match any of the layouts.
+type DateParseError struct {
+	Value   string
+	Layouts []string
+}
+
+func (e *DateParseError) Error() string {
+	return fmt.Sprintf("cannot parse %q as a date, tried layouts: %q", e.Value, e.Layouts)
 }
 
 // ParseDate parses a date string from multiple layouts.
 //
-// The following layouts are tried:
+// The following layouts are tried, followed by any registered layouts:
 //   - "2006-01-02"
 //   - "January-2-2006"
 //   - "2-January-2006"
 //   - "January 2, 2006"
+//   - "2006-01-02T15:04:05Z07:00" (RFC3339)
+//   - "01/02/2006"
+//   - "2 Jan 2006"
+//   - "January 2006"
+//   - "Jan 2006"
+//   - "2006-01"
+//
+// If no layout matches, a *DateParseError is returned.
 func ParseDate(val string) (time.Time, error) {
-	var date time.Time
-	var err error
-	for _, format := range dateFormats {
-		date, err = time.Parse(format, val)
+	dateFormatsMu.RLock()
+	layouts := slices.Clone(dateFormats)
+	dateFormatsMu.RUnlock()
+
+	return parseLayouts(val, layouts)
+}
+
+// dateTimeFormats are the layouts tried by ParseDateTime.
+var dateTimeFormats = []string{
+	time.RFC3339,          // Timestamp
+	"2006-01-02T15:04:05", // Datetime-local, with seconds
+	"2006-01-02T15:04",    // Datetime-local
+	"2006-01-02 15:04:05", // Short, with seconds
+	"2006-01-02 15:04",    // Short
+}
+
+// ParseDateTime parses a date and time string, like the value of a
+// datetime-local input, from multiple layouts.
+//
+// The following layouts are tried:
+//   - "2006-01-02T15:04:05Z07:00" (RFC3339)
+//   - "2006-01-02T15:04:05"
+//   - "2006-01-02T15:04"
+//   - "2006-01-02 15:04:05"
+//   - "2006-01-02 15:04"
+//
+// Values without a timezone are in UTC. If no layout matches,
+// a *DateParseError is returned.
+func ParseDateTime(val string) (time.Time, error) {
+	return parseLayouts(val, dateTimeFormats)
+}
+
+// timeFormats are the layouts tried by ParseTime.
+var timeFormats = []string{
+	"15:04",    // 24-hour
+	"15:04:05", // 24-hour, with seconds
+	"3:04 PM",  // 12-hour
+	"3:04PM",   // 12-hour, without a space
+	"3:04 pm",  // 12-hour, lowercase
+	"3:04pm",   // 12-hour, lowercase without a space
+}
+
+// ParseTime parses a time of day string, like "15:04" or "3:04 PM".
+// The date of the returned time.Time is January 1, year 0, in UTC.
+//
+// If no layout matches, a *DateParseError is returned.
+func ParseTime(val string) (time.Time, error) {
+	return parseLayouts(val, timeFormats)
+}
+
+// parseLayouts parses the value with the first matching layout.
+func parseLayouts(val string, layouts []string) (time.Time, error) {
+	for _, layout := range layouts {
 		// If the time parsing fails,
-		// then try the next format.
-		if err != nil {
-			continue
+		// then try the next layout.
+		if date, err := time.Parse(layout, val); err == nil {
+			return date, nil
 		}
-		// If there is no error, then
-		// return the parsed time.Time.
-		return date, nil
 	}
-	return time.Time{}, err
+	return time.Time{}, &DateParseError{Value: val, Layouts: layouts}
 }
@@ -0,0 +1,265 @@
+package format
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Relative Time Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestRelativeTime(t *testing.T) {
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{-30 * time.Second, "just now"},
+		{30 * time.Second, "in less than a minute"},
+		{-1 * time.Minute, "1 minute ago"},
+		{-150 * time.Minute, "2 hours ago"},
+		{3 * time.Hour, "in 3 hours"},
+		{-25 * time.Hour, "1 day ago"},
+		{-15 * 24 * time.Hour, "2 weeks ago"},
+		{60 * 24 * time.Hour, "in 2 months"},
+		{-800 * 24 * time.Hour, "2 years ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, relativeTime(tt.offset), tt.want)
+		})
+	}
+
+	t.Run("TimeAgo", func(t *testing.T) {
+		assert.Equal(t, TimeAgo(time.Now().Add(-90*time.Minute)), "1 hour ago")
+		assert.Equal(t, TimeUntil(time.Now().Add(90*time.Minute+time.Second)), "in 1 hour")
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Duration Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestDuration(t *testing.T) {
+	day := 24 * time.Hour
+
+	t.Run("Duration", func(t *testing.T) {
+		assert.Equal(t, Duration(0), "0s")
+		assert.Equal(t, Duration(500*time.Millisecond), "0s")
+		assert.Equal(t, Duration(45*time.Second), "45s")
+		assert.Equal(t, Duration(2*time.Hour+15*time.Minute+10*time.Second), "2h 15m")
+		assert.Equal(t, Duration(3*day+4*time.Hour+5*time.Minute), "3d 4h")
+		assert.Equal(t, Duration(day+5*time.Minute), "1d")
+		assert.Equal(t, Duration(-90*time.Second), "-1m 30s")
+	})
+
+	t.Run("DurationPrecision", func(t *testing.T) {
+		d := 3*day + 4*time.Hour + 12*time.Minute + 7*time.Second
+		assert.Equal(t, DurationPrecision(d, 1), "3d")
+		assert.Equal(t, DurationPrecision(d, 3), "3d 4h 12m")
+		assert.Equal(t, DurationPrecision(d, 4), "3d 4h 12m 7s")
+	})
+
+	t.Run("ParseDuration", func(t *testing.T) {
+		tests := map[string]time.Duration{
+			"2h 15m":  2*time.Hour + 15*time.Minute,
+			"3d 4h":   3*day + 4*time.Hour,
+			"1d2h":    day + 2*time.Hour,
+			"1h30m":   90 * time.Minute,
+			"-1m 30s": -90 * time.Second,
+			" 7d ":    7 * day,
+		}
+		for val, want := range tests {
+			d, err := ParseDuration(val)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, d, want)
+		}
+
+		for _, val := range []string{"", "-", "+", " - ", "abc", "1x", "d", "2d-1h", "1h -5m"} {
+			_, err := ParseDuration(val)
+			assert.Equal(t, err != nil, true)
+		}
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Date Parsers
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestParseDate(t *testing.T) {
+	t.Run("layouts", func(t *testing.T) {
+		tests := map[string]time.Time{
+			"2020-07-23":           time.Date(2020, 7, 23, 0, 0, 0, 0, time.UTC),
+			"July-23-2020":         time.Date(2020, 7, 23, 0, 0, 0, 0, time.UTC),
+			"23-July-2020":         time.Date(2020, 7, 23, 0, 0, 0, 0, time.UTC),
+			"July 23, 2020":        time.Date(2020, 7, 23, 0, 0, 0, 0, time.UTC),
+			"2020-07-23T10:30:00Z": time.Date(2020, 7, 23, 10, 30, 0, 0, time.UTC),
+			"07/23/2020":           time.Date(2020, 7, 23, 0, 0, 0, 0, time.UTC),
+			"23 Jul 2020":          time.Date(2020, 7, 23, 0, 0, 0, 0, time.UTC),
+			"July 2020":            time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+			"Jul 2020":             time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+			"2020-07":              time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+		}
+		for val, want := range tests {
+			date, err := ParseDate(val)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, date.Equal(want), true)
+		}
+	})
+
+	t.Run("DateParseError", func(t *testing.T) {
+		_, err := ParseDate("23.07.2020")
+
+		var parseErr *DateParseError
+		assert.Equal(t, errors.As(err, &parseErr), true)
+		assert.Equal(t, parseErr.Value, "23.07.2020")
+		assert.Equal(t, parseErr.Layouts[0], "2006-01-02")
+	})
+
+	t.Run("RegisterDateLayout", func(t *testing.T) {
+		defer func(layouts []string) { dateFormats = layouts }(slices.Clone(dateFormats))
+
+		RegisterDateLayout("02.01.2006")
+		date, err := ParseDate("23.07.2020")
+
+		assert.Equal(t, err, nil)
+		assert.Equal(t, date, time.Date(2020, 7, 23, 0, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("ParseDateTime", func(t *testing.T) {
+		want := time.Date(2020, 7, 23, 14, 30, 0, 0, time.UTC)
+		for _, val := range []string{"2020-07-23T14:30:00Z", "2020-07-23T14:30", "2020-07-23T14:30:00", "2020-07-23 14:30"} {
+			date, err := ParseDateTime(val)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, date.Equal(want), true)
+		}
+
+		_, err := ParseDateTime("2020-07-23")
+		assert.Equal(t, err != nil, true)
+	})
+
+	t.Run("ParseTime", func(t *testing.T) {
+		for _, val := range []string{"14:30", "14:30:00", "2:30 PM", "2:30PM", "2:30 pm", "2:30pm"} {
+			tm, err := ParseTime(val)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, tm, time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC))
+		}
+
+		_, err := ParseTime("25:00")
+		var parseErr *DateParseError
+		assert.Equal(t, errors.As(err, &parseErr), true)
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Clock
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestClock(t *testing.T) {
+	defer SetClock(nil)
+	defer SetLocation(nil)
+
+	// 2024-03-10 02:30 UTC is still March 9th in New York.
+	SetClock(func() time.Time { return time.Date(2024, 3, 10, 2, 30, 0, 0, time.UTC) })
+	assert.Equal(t, Today(), time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("timezone data is not available")
+	}
+	SetLocation(newYork)
+
+	assert.Equal(t, Date(Today()), "2024-03-09")
+	assert.Equal(t, Today().Location(), newYork)
+	assert.Equal(t, TimeAgo(time.Date(2024, 3, 9, 2, 30, 0, 0, time.UTC)), "1 day ago")
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Date Math
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestDateMath(t *testing.T) {
+	// Thursday, August 15th.
+	d := time.Date(2024, 8, 15, 13, 45, 0, 0, time.UTC)
+	date := func(y int, m time.Month, day int) time.Time {
+		return time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+	}
+	last := func(y int, m time.Month, day int) time.Time {
+		return date(y, m, day).AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	t.Run("StartOf", func(t *testing.T) {
+		assert.Equal(t, StartOfWeek(d), date(2024, 8, 12))
+		assert.Equal(t, StartOfWeek(date(2024, 8, 18)), date(2024, 8, 12))
+		assert.Equal(t, StartOfMonth(d), date(2024, 8, 1))
+		assert.Equal(t, StartOfQuarter(d), date(2024, 7, 1))
+		assert.Equal(t, StartOfYear(d), date(2024, 1, 1))
+	})
+
+	t.Run("EndOf", func(t *testing.T) {
+		assert.Equal(t, EndOfWeek(d), last(2024, 8, 18))
+		assert.Equal(t, EndOfMonth(date(2024, 2, 10)), last(2024, 2, 29))
+		assert.Equal(t, EndOfQuarter(d), last(2024, 9, 30))
+		assert.Equal(t, EndOfYear(d), last(2024, 12, 31))
+	})
+
+	t.Run("AddBusinessDays", func(t *testing.T) {
+		assert.Equal(t, AddBusinessDays(d, 0), d)
+		assert.Equal(t, AddBusinessDays(d, 1).Day(), 16)
+		assert.Equal(t, AddBusinessDays(d, 2).Day(), 19)
+		assert.Equal(t, AddBusinessDays(d, 6).Day(), 23)
+		assert.Equal(t, AddBusinessDays(d, -4).Day(), 9)
+		assert.Equal(t, AddBusinessDays(date(2024, 8, 17), 1).Day(), 19)
+	})
+
+	t.Run("DaysBetween", func(t *testing.T) {
+		assert.Equal(t, DaysBetween(d, date(2024, 8, 15)), 0)
+		assert.Equal(t, DaysBetween(d, date(2024, 9, 1)), 17)
+		assert.Equal(t, DaysBetween(date(2025, 1, 1), d), -139)
+	})
+}
@@ -1,3 +1,61 @@
 // Package format implements functions to format numbers,
 // dates, times and strings.
 package format
+
+import (
+	"html/template"
+
+	"github.com/tunedmystic/rio/decimal"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Template Functions
+//
+//
+// ------------------------------------------------------------------
+
+// FuncMap returns the formatting functions for use in templates.
+// A rio View includes them by default.
+//
+//	{{ .CreatedAt | datenatural }}
+//	{{ bytes .Size }}
+//	{{ pluralizecount .Count "item" }}
+//
+// .
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"abbrev":         Abbrev,
+		"bytes":          Bytes,
+		"bytesiec":       BytesIEC,
+		"date":           Date,
+		"datenatural":    DateNatural,
+		"datetime":       DateTime,
+		"decimal":        decimalString,
+		"duration":       Duration,
+		"float":          Float,
+		"integer":        Integer,
+		"ordinal":        Ordinal,
+		"percent":        Percent,
+		"plural":         Plural[int],
+		"pluralize":      Pluralize[int],
+		"pluralizecount": PluralizeCount[int],
+		"slugify":        Slugify,
+		"striptags":      StripTags,
+		"time":           Time,
+		"timeago":        TimeAgo,
+		"timeuntil":      TimeUntil,
+		"title":          Title,
+		"titlefirst":     TitleFirst,
+	}
+}
+
+// decimalString formats a decimal to the number of places, grouped by thousands.
+//
+//	{{ decimal .Total 2 }}
+//
+// .
+func decimalString(d decimal.Decimal, places int) string {
+	return d.FormatString(places, true)
+}
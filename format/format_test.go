@@ -0,0 +1,39 @@
+package format
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tunedmystic/rio/assert"
+	"github.com/tunedmystic/rio/decimal"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Template Functions
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestFuncMap(t *testing.T) {
+	src := `{{ datenatural .Date }}|{{ bytes .Size }}|{{ pluralizecount .Count "item" }}|{{ decimal .Total 2 }}|{{ ordinal 3 }}`
+	tmpl := template.Must(template.New("").Funcs(FuncMap()).Parse(src))
+
+	data := map[string]any{
+		"Date":  time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC),
+		"Size":  int64(1_400_000),
+		"Count": 3,
+		"Total": decimal.MustParse("1234.5"),
+	}
+
+	var b strings.Builder
+	assert.Equal(t, tmpl.Execute(&b, data), nil)
+	assert.Equal(t, b.String(), "August 15, 2024|1.4 MB|3 items|1,234.50|3rd")
+}
@@ -1,6 +1,7 @@
 package format
 
 import (
+	"fmt"
 	"math"
 	"math/big"
 	"strconv"
@@ -15,13 +16,37 @@ import (
 //
 // ------------------------------------------------------------------
 
-// Integer formats an integer to a string based on a user-specified format.
+// Integer formats an integer to a string based on a user-specified format,
+// like "#,###.##". A malformed format falls back to the default format.
+// Use FormatInteger to check the format.
 func Integer(n int, format string) string {
-	return renderFloat(float64(n), format)
+	s, err := renderInt(n, format)
+	if err != nil {
+		s, _ = renderInt(n, "")
+	}
+	return s
 }
 
-// Float formats a float64 to a string based on a user-specified format.
+// Float formats a float64 to a string based on a user-specified format,
+// like "#,###.##". A malformed format falls back to the default format.
+// Use FormatFloat to check the format.
 func Float(n float64, format string) string {
+	s, err := renderFloat(n, format)
+	if err != nil {
+		s, _ = renderFloat(n, "")
+	}
+	return s
+}
+
+// FormatInteger formats an integer to a string based on a user-specified format.
+// It returns a *NumberFormatError if the format is malformed.
+func FormatInteger(n int, format string) (string, error) {
+	return renderInt(n, format)
+}
+
+// FormatFloat formats a float64 to a string based on a user-specified format.
+// It returns a *NumberFormatError if the format is malformed.
+func FormatFloat(n float64, format string) (string, error) {
 	return renderFloat(n, format)
 }
 
@@ -69,6 +94,185 @@ type Num interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
 }
 
+// ------------------------------------------------------------------
+//
+//
+// Abbreviated Number Formatters
+//
+//
+// ------------------------------------------------------------------
+
+// abbrevUnits are the suffixes of abbreviated numbers.
+var abbrevUnits = []string{"", "K", "M", "B", "T"}
+
+// Abbrev formats a number with a compact suffix, like "1.2K" or "3.4M",
+// with up to the given number of decimals. Trailing zeros are removed.
+func Abbrev(n float64, decimals int) string {
+	decimals = max(0, min(decimals, 9))
+
+	format := "#,###." + strings.Repeat("#", decimals)
+
+	num, suffix := abbrev(n, decimals)
+	return trimDecimals(Float(num, format), decimals) + suffix
+}
+
+// AbbrevFormat formats a number with a compact suffix, where the number
+// is formatted with the given format, like Float. This allows for
+// locale-specific separators, like "#.###,#" for "1,2K".
+func AbbrevFormat(n float64, format string) string {
+	num, suffix := abbrev(n, 9)
+	return Float(num, format) + suffix
+}
+
+// abbrev scales the number down to the largest suffix, rounding to the
+// decimals first so that 999,999 becomes "1M" rather than "1000K".
+func abbrev(n float64, decimals int) (float64, string) {
+	scale := math.Pow(10, float64(decimals))
+	i := 0
+	for i < len(abbrevUnits)-1 && math.Abs(math.Round(n*scale)/scale) >= 1000 {
+		n /= 1000
+		i++
+	}
+	return n, abbrevUnits[i]
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Percent Formatters
+//
+//
+// ------------------------------------------------------------------
+
+// Percent formats a ratio as a percentage, like 0.256 => "25.6%",
+// with up to the given number of decimals. Trailing zeros are removed,
+// so whole numbers are formatted like "50%".
+func Percent(ratio float64, decimals int) string {
+	decimals = max(0, min(decimals, 9))
+
+	format := "#,###." + strings.Repeat("#", decimals)
+
+	return trimDecimals(Float(ratio*100, format), decimals) + "%"
+}
+
+// trimDecimals removes the trailing zeros of a number formatted with decimals.
+// Unlike TrimZero, the zeros of whole numbers are kept.
+func trimDecimals(s string, decimals int) string {
+	if decimals == 0 {
+		return s
+	}
+	return strings.TrimRight(strings.TrimRight(s, "0"), ".")
+}
+
+// PercentOf formats the part of the whole as a percentage, like
+// PercentOf(1, 3, 1) => "33.3%". A zero whole is formatted as "0%".
+func PercentOf[T Num](part, whole T, decimals int) string {
+	if whole == 0 {
+		return "0%"
+	}
+	return Percent(float64(part)/float64(whole), decimals)
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Byte Size Formatters
+//
+//
+// ------------------------------------------------------------------
+
+var (
+	siByteUnits  = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+	iecByteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+)
+
+// Bytes formats a byte size with SI units (powers of 1000), like "1.4 MB".
+func Bytes(n int64) string {
+	return formatBytes(n, 1000, siByteUnits)
+}
+
+// BytesIEC formats a byte size with IEC units (powers of 1024), like "1.4 MiB".
+func BytesIEC(n int64) string {
+	return formatBytes(n, 1024, iecByteUnits)
+}
+
+// formatBytes formats the byte size in the largest unit, with 1 decimal.
+func formatBytes(n int64, base float64, units []string) string {
+	sign := ""
+	size := float64(n)
+	if size < 0 {
+		sign = "-"
+		size = -size
+	}
+
+	i := 0
+	for size >= base && i < len(units)-1 {
+		size /= base
+		i++
+	}
+
+	if i == 0 {
+		return fmt.Sprintf("%s%d %s", sign, int64(size), units[i])
+	}
+	return sign + TrimZero(strconv.FormatFloat(size, 'f', 1, 64)) + " " + units[i]
+}
+
+// byteUnits are the multipliers of the byte size units, in lowercase.
+var byteUnits = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"m":   1e6,
+	"mb":  1e6,
+	"g":   1e9,
+	"gb":  1e9,
+	"t":   1e12,
+	"tb":  1e12,
+	"p":   1e15,
+	"pb":  1e15,
+	"e":   1e18,
+	"eb":  1e18,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+	"eib": 1 << 60,
+}
+
+// ParseBytes parses a byte size, like "10MiB", "1.5 GB" or "512".
+//
+// Units are case-insensitive. SI units, like "MB", are powers of 1000,
+// and IEC units, like "MiB", are powers of 1024.
+func ParseBytes(val string) (int64, error) {
+	s := strings.TrimSpace(val)
+
+	// Split the number from the unit.
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+
+	n, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", val)
+	}
+	mult, ok := byteUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size unit %q", val)
+	}
+
+	size := n * mult
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("byte size %q is too large", val)
+	}
+	return int64(size), nil
+}
+
 // ------------------------------------------------------------------
 //
 //
@@ -108,167 +312,192 @@ func ordinal(n int) string {
 //
 // ------------------------------------------------------------------
 
-// renderFloat renders a float64 value to a string based on the following user-specific criteria:
+// NumberFormatError is returned when a number format is malformed.
+type NumberFormatError struct {
+	Format string
+	Reason string
+}
+
+func (e *NumberFormatError) Error() string {
+	return fmt.Sprintf("invalid number format %q: %s", e.Format, e.Reason)
+}
+
+// numberFormat is a parsed number format.
+type numberFormat struct {
+	precision int
+	decimal   string
+	thousand  string
+	positive  string
+}
+
+// defaultNumberFormat is the format used for an empty format string, like "12,345.67".
+var defaultNumberFormat = numberFormat{precision: 2, decimal: ".", thousand: ","}
+
+// parseNumberFormat parses the format string, based on the following user-specific criteria:
+//   - positive sign
 //   - thousands separator
 //   - decimal separator
 //   - decimal precision
 //
 // Examples of format strings, given n = 12345.6789:
-//   - "#,###.##" => "12,345.67"
-//   - "#,###." => "12,345"
-//   - "#,###" => "12345,678"
-//   - "#\u202F###,##" => "12 345,67"
+//   - "#,###.##" => "12,345.68"
+//   - "#,###." => "12,346"
+//   - "#,###" => "12345,679"
+//   - "+#,###.##" => "+12,345.68"
+//   - "#\u202F###,##" => "12 345,68"
 //   - "#.###,###### => 12.345,678900
-//   - "" (aka default format) => 12,345.67
+//   - "" (aka default format) => 12,345.68
 //
-// The highest precision allowed is 9 digits after the decimal symbol.
+// Any character other than "#" and "0" is a directive. A leading "+" is the
+// positive sign. The last directive is the decimal separator, and the number
+// of characters after it is the precision. The directives before it are the
+// thousands separator, which must be followed by 3 digit-specifiers.
+// A format without directives has a precision of 9.
 //
-// All credit goes to the original author.
+// The syntax is based on the original renderFloat by gorhill.
 // Ref: https://gist.github.com/gorhill/5285193
-func renderFloat(n float64, format string) string {
-	// Special cases:
-	//   NaN = "NaN"
-	//   +Inf = "+Infinity"
-	//   -Inf = "-Infinity"
-	if math.IsNaN(n) {
-		return "NaN"
+func parseNumberFormat(format string) (numberFormat, error) {
+	if format == "" {
+		return defaultNumberFormat, nil
 	}
-	if n > math.MaxFloat64 {
-		return "Infinity"
+
+	nf := numberFormat{precision: 9, decimal: "."}
+	chars := []rune(format)
+
+	// collect indices of meaningful formatting directives
+	var directives []int
+	for i, char := range chars {
+		if char != '#' && char != '0' {
+			directives = append(directives, i)
+		}
 	}
-	if n < -math.MaxFloat64 {
-		return "-Infinity"
+
+	// A directive at index 0 must be a '+'.
+	if len(directives) > 0 && directives[0] == 0 {
+		if chars[0] != '+' {
+			return nf, &NumberFormatError{format, "invalid positive sign directive"}
+		}
+		nf.positive = "+"
+		directives = directives[1:]
 	}
 
-	// default format
-	precision := 2
-	decimalStr := "."
-	thousandStr := ","
-	positiveStr := ""
-	negativeStr := "-"
-
-	if len(format) > 0 {
-		// If there is an explicit format directive,
-		// then default values are these:
-		precision = 9
-		thousandStr = ""
-
-		// collect indices of meaningful formatting directives
-		formatDirectiveChars := []rune(format)
-		formatDirectiveIndices := make([]int, 0)
-		for i, char := range formatDirectiveChars {
-			if char != '#' && char != '0' {
-				formatDirectiveIndices = append(formatDirectiveIndices, i)
-			}
+	if len(directives) == 0 {
+		return nf, nil
+	}
+
+	// The directives before the last are thousands separators.
+	last := directives[len(directives)-1]
+	for i, idx := range directives[:len(directives)-1] {
+		if chars[idx] != chars[directives[0]] || directives[i+1]-idx != 4 {
+			return nf, &NumberFormatError{format, "thousands separator directive must be followed by 3 digit-specifiers"}
 		}
+		nf.thousand = string(chars[idx])
+	}
 
-		if len(formatDirectiveIndices) > 0 {
-			// Directive at index 0:
-			//   Must be a '+'
-			//   Raise an error if not the case
-			// index: 0123456789
-			//        +0.000,000
-			//        +000,000.0
-			//        +0000.00
-			//        +0000
-			if formatDirectiveIndices[0] == 0 {
-				if formatDirectiveChars[formatDirectiveIndices[0]] != '+' {
-					panic("RenderFloat(): invalid positive sign directive")
-				}
-				positiveStr = "+"
-				formatDirectiveIndices = formatDirectiveIndices[1:]
-			}
+	nf.decimal = string(chars[last])
+	nf.precision = len(chars) - last - 1
+	return nf, nil
+}
 
-			// Two directives:
-			//   First is thousands separator
-			//   Raise an error if not followed by 3-digit
-			// 0123456789
-			// 0.000,000
-			// 000,000.00
-			if len(formatDirectiveIndices) == 2 {
-				if (formatDirectiveIndices[1] - formatDirectiveIndices[0]) != 4 {
-					panic("RenderFloat(): thousands separator directive must be followed by 3 digit-specifiers")
-				}
-				thousandStr = string(formatDirectiveChars[formatDirectiveIndices[0]])
-				formatDirectiveIndices = formatDirectiveIndices[1:]
-			}
+// renderFloat renders a float64 value to a string based on the format.
+// See parseNumberFormat for the format syntax.
+//
+// The number is rounded half away from zero, based on its shortest
+// decimal representation, so that 2.675 is rounded to "2.68".
+func renderFloat(n float64, format string) (string, error) {
+	nf, err := parseNumberFormat(format)
+	if err != nil {
+		return "", err
+	}
 
-			// One directive:
-			//   Directive is decimal separator
-			//   The number of digit-specifier following the separator indicates wanted precision
-			// 0123456789
-			// 0.00
-			// 000,0000
-			if len(formatDirectiveIndices) == 1 {
-				decimalStr = string(formatDirectiveChars[formatDirectiveIndices[0]])
-				precision = len(formatDirectiveChars) - formatDirectiveIndices[0] - 1
-			}
-		}
+	// Special cases:
+	//   NaN = "NaN"
+	//   +Inf = "Infinity"
+	//   -Inf = "-Infinity"
+	switch {
+	case math.IsNaN(n):
+		return "NaN", nil
+	case math.IsInf(n, 1):
+		return "Infinity", nil
+	case math.IsInf(n, -1):
+		return "-Infinity", nil
 	}
 
-	// generate sign part
-	var signStr string
-	if n >= 0.000000001 {
-		signStr = positiveStr
-	} else if n <= -0.000000001 {
-		signStr = negativeStr
-		n = -n
-	} else {
-		signStr = ""
-		n = 0.0
+	digits := strconv.FormatFloat(math.Abs(n), 'f', -1, 64)
+	intStr, fracStr, _ := strings.Cut(digits, ".")
+	return nf.render(math.Signbit(n), intStr, fracStr), nil
+}
+
+// renderInt renders an int value to a string based on the format,
+// without the loss of precision of a float64.
+func renderInt(n int, format string) (string, error) {
+	nf, err := parseNumberFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	u := uint64(n)
+	if n < 0 {
+		u = -u
 	}
+	return nf.render(n < 0, strconv.FormatUint(u, 10), ""), nil
+}
 
-	// split number into integer and fractional parts
-	intf, fracf := math.Modf(n + renderFloatPrecisionRounders[precision])
+// render formats the digits of a number, rounded to the precision.
+func (nf numberFormat) render(negative bool, intStr, fracStr string) string {
+	intStr, fracStr = roundDigits(intStr, fracStr, nf.precision)
 
-	// generate integer part string
-	intStr := strconv.Itoa(int(intf))
+	// generate sign part, where zero has no sign
+	signStr := nf.positive
+	if negative {
+		signStr = "-"
+	}
+	if strings.Trim(intStr+fracStr, "0") == "" {
+		signStr = ""
+	}
 
 	// add thousand separator if required
-	if len(thousandStr) > 0 {
-		for i := len(intStr); i > 3; {
-			i -= 3
-			intStr = intStr[:i] + thousandStr + intStr[i:]
+	if nf.thousand != "" && len(intStr) > 3 {
+		var b strings.Builder
+		for i, c := range intStr {
+			if i > 0 && (len(intStr)-i)%3 == 0 {
+				b.WriteString(nf.thousand)
+			}
+			b.WriteRune(c)
 		}
+		intStr = b.String()
 	}
 
 	// no fractional part, we can leave now
-	if precision == 0 {
+	if nf.precision == 0 {
 		return signStr + intStr
 	}
+	return signStr + intStr + nf.decimal + fracStr
+}
+
+// roundDigits rounds the decimal digits to the precision, half away from zero,
+// and pads the fractional part with zeros.
+func roundDigits(intStr, fracStr string, precision int) (string, string) {
+	if len(fracStr) <= precision {
+		return intStr, fracStr + strings.Repeat("0", precision-len(fracStr))
+	}
 
-	// generate fractional part
-	fracStr := strconv.Itoa(int(fracf * renderFloatPrecisionMultipliers[precision]))
-	// may need padding
-	if len(fracStr) < precision {
-		fracStr = "000000000000000"[:precision-len(fracStr)] + fracStr
+	roundUp := fracStr[precision] >= '5'
+	digits := []byte(intStr + fracStr[:precision])
+
+	// carry the rounding through the digits
+	for i := len(digits) - 1; roundUp && i >= 0; i-- {
+		if digits[i] == '9' {
+			digits[i] = '0'
+			continue
+		}
+		digits[i]++
+		roundUp = false
+	}
+	if roundUp {
+		digits = append([]byte{'1'}, digits...)
 	}
 
-	return signStr + intStr + decimalStr + fracStr
-}
-
-var renderFloatPrecisionMultipliers = [10]float64{
-	1,
-	10,
-	100,
-	1000,
-	10000,
-	100000,
-	1000000,
-	10000000,
-	100000000,
-	1000000000,
-}
-
-var renderFloatPrecisionRounders = [10]float64{
-	0.5,
-	0.05,
-	0.005,
-	0.0005,
-	0.00005,
-	0.000005,
-	0.0000005,
-	0.00000005,
-	0.000000005,
-	0.0000000005,
+	split := len(digits) - precision
+	return string(digits[:split]), string(digits[split:])
 }
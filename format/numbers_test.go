@@ -0,0 +1,230 @@
+package format
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Number Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestFloat(t *testing.T) {
+	t.Run("formats", func(t *testing.T) {
+		n := 12345.6789
+		tests := map[string]string{
+			"":              "12,345.68",
+			"#,###.##":      "12,345.68",
+			"#,###.":        "12,346",
+			"#,###":         "12345,679",
+			"+#,###.##":     "+12,345.68",
+			"#\u202F###,##": "12\u202F345,68",
+			"#.###,######":  "12.345,678900",
+			"####":          "12345.678900000",
+			"#,###,###.#":   "12,345.7",
+			"#.###.###,###": "12.345,679",
+		}
+		for format, want := range tests {
+			assert.Equal(t, Float(n, format), want)
+		}
+	})
+
+	t.Run("rounding", func(t *testing.T) {
+		assert.Equal(t, Float(2.675, "#.##"), "2.68")
+		assert.Equal(t, Float(0.125, "#.##"), "0.13")
+		assert.Equal(t, Float(-0.125, "#.##"), "-0.13")
+		assert.Equal(t, Float(9.995, "#,###.##"), "10.00")
+		assert.Equal(t, Float(999.9, "#,###."), "1,000")
+		assert.Equal(t, Float(1.005, "#.##"), "1.01")
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		assert.Equal(t, Float(0, "+#.##"), "0.00")
+		assert.Equal(t, Float(math.Copysign(0, -1), ""), "0.00")
+		assert.Equal(t, Float(-0.001, "#.##"), "0.00")
+		assert.Equal(t, Float(-0.005, "#.##"), "-0.01")
+	})
+
+	t.Run("huge values", func(t *testing.T) {
+		assert.Equal(t, Float(1e20, "#,###."), "100,000,000,000,000,000,000")
+		assert.Equal(t, Float(-1e15, ""), "-1,000,000,000,000,000.00")
+		assert.Equal(t, Integer(math.MaxInt64, "#,###."), "9,223,372,036,854,775,807")
+		assert.Equal(t, Integer(math.MinInt64, "#,###."), "-9,223,372,036,854,775,808")
+		assert.Equal(t, Integer(-1234, ""), "-1,234.00")
+	})
+
+	t.Run("special values", func(t *testing.T) {
+		assert.Equal(t, Float(math.NaN(), ""), "NaN")
+		assert.Equal(t, Float(math.Inf(1), ""), "Infinity")
+		assert.Equal(t, Float(math.Inf(-1), ""), "-Infinity")
+	})
+
+	t.Run("malformed formats", func(t *testing.T) {
+		for _, format := range []string{"-#.##", "#,##.##", "#,###,##.#", "#,###;###.#"} {
+			_, err := FormatFloat(1234.5, format)
+			var formatErr *NumberFormatError
+			assert.Equal(t, errors.As(err, &formatErr), true)
+			assert.Equal(t, formatErr.Format, format)
+
+			_, err = FormatInteger(1234, format)
+			assert.Equal(t, err != nil, true)
+
+			// Float and Integer fall back to the default format.
+			assert.Equal(t, Float(1234.5, format), "1,234.50")
+			assert.Equal(t, Integer(1234, format), "1,234.00")
+		}
+	})
+}
+
+func FuzzFloat(f *testing.F) {
+	f.Add(12345.6789, "#,###.##")
+	f.Add(-0.005, "+#.##")
+	f.Add(1e300, "")
+	f.Add(math.MaxFloat64, "#.###,######")
+	f.Add(0.0, "-")
+	f.Add(1.5, "#,##")
+
+	f.Fuzz(func(t *testing.T, n float64, format string) {
+		s, err := FormatFloat(n, format)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return
+		}
+
+		// With the default format, the output parses back to the rounded number.
+		s, _ = FormatFloat(n, "")
+		got, err := strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+		if err != nil {
+			t.Fatalf("cannot parse %q: %v", s, err)
+		}
+		if diff := math.Abs(got - n); diff > 0.005+math.Abs(n)*1e-15 {
+			t.Fatalf("Float(%v) = %q, off by %v", n, s, diff)
+		}
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Byte Size Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestBytes(t *testing.T) {
+	t.Run("Bytes", func(t *testing.T) {
+		assert.Equal(t, Bytes(0), "0 B")
+		assert.Equal(t, Bytes(999), "999 B")
+		assert.Equal(t, Bytes(1000), "1 kB")
+		assert.Equal(t, Bytes(1_400_000), "1.4 MB")
+		assert.Equal(t, Bytes(-2_500_000_000), "-2.5 GB")
+	})
+
+	t.Run("BytesIEC", func(t *testing.T) {
+		assert.Equal(t, BytesIEC(1023), "1023 B")
+		assert.Equal(t, BytesIEC(1024), "1 KiB")
+		assert.Equal(t, BytesIEC(1536*1024), "1.5 MiB")
+	})
+
+	t.Run("ParseBytes", func(t *testing.T) {
+		tests := map[string]int64{
+			"512":    512,
+			"10MiB":  10 << 20,
+			"1.5 GB": 1_500_000_000,
+			"2kb":    2000,
+			"4 KiB":  4096,
+			"1 b":    1,
+		}
+		for val, want := range tests {
+			n, err := ParseBytes(val)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, n, want)
+		}
+
+		for _, val := range []string{"", "MB", "10 XB", "-5 MB", "1..2 MB", "100 EB"} {
+			_, err := ParseBytes(val)
+			assert.Equal(t, err != nil, true)
+		}
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Abbreviated Number Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestAbbrev(t *testing.T) {
+	tests := []struct {
+		n        float64
+		decimals int
+		want     string
+	}{
+		{0, 1, "0"},
+		{999, 1, "999"},
+		{1234, 1, "1.2K"},
+		{1000, 1, "1K"},
+		{15_300, 0, "15K"},
+		{3_456_789, 2, "3.46M"},
+		{999_999, 1, "1M"},
+		{2_000_000_000, 1, "2B"},
+		{-1_500, 1, "-1.5K"},
+		{1_200_000_000_000_000, 1, "1,200T"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, Abbrev(tt.n, tt.decimals), tt.want)
+		})
+	}
+
+	t.Run("AbbrevFormat", func(t *testing.T) {
+		assert.Equal(t, AbbrevFormat(1234, "#.###,#"), "1,2K")
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Percent Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestPercent(t *testing.T) {
+	assert.Equal(t, Percent(0.256, 1), "25.6%")
+	assert.Equal(t, Percent(0.5, 2), "50%")
+	assert.Equal(t, Percent(0.12345, 2), "12.35%")
+	assert.Equal(t, Percent(12.5, 0), "1,250%")
+	assert.Equal(t, Percent(-0.031, 1), "-3.1%")
+
+	assert.Equal(t, PercentOf(1, 3, 1), "33.3%")
+	assert.Equal(t, PercentOf(2.0, 8.0, 2), "25%")
+	assert.Equal(t, PercentOf(5, 0, 2), "0%")
+}
@@ -1,15 +1,406 @@
 package format
 
-import "strings"
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
 
+// ------------------------------------------------------------------
+//
+//
+// Title Formatters
+//
+//
+// ------------------------------------------------------------------
+
+// SmallWords are the english words which are not capitalized in titles,
+// for use with TitleSkip.
+var SmallWords = []string{
+	"a", "an", "and", "as", "at", "but", "by", "for", "from",
+	"in", "nor", "of", "on", "or", "the", "to", "with",
+}
+
+// Title capitalizes the first letter of each word, like "hello world" => "Hello World".
 func Title(s string) string {
-	var strs []string
-	for _, item := range strings.Split(s, " ") {
-		strs = append(strs, TitleFirst(item))
+	return TitleSkip(s)
+}
+
+// TitleSkip capitalizes the first letter of each word, except for the given words,
+// which are lowercased. The first word is always capitalized.
+//
+//	TitleSkip("the lord of the rings", SmallWords...) // "The Lord of the Rings"
+//
+// .
+func TitleSkip(s string, skip ...string) string {
+	words := strings.Split(s, " ")
+	first := true
+
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		if !first && slices.ContainsFunc(skip, func(w string) bool { return strings.EqualFold(w, word) }) {
+			words[i] = strings.ToLower(word)
+		} else {
+			words[i] = TitleFirst(word)
+		}
+		first = false
 	}
-	return strings.Join(strs, " ")
+	return strings.Join(words, " ")
 }
 
+// TitleFirst capitalizes the first letter of the string, like "élan" => "Élan".
 func TitleFirst(s string) string {
-	return strings.ToUpper(s[:1]) + s[1:]
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToTitle(r)) + s[size:]
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Plural Formatters
+//
+//
+// ------------------------------------------------------------------
+
+var (
+	pluralMu   sync.RWMutex
+	pluralizer = englishPlural
+	irregulars = map[string]string{
+		"child": "children", "person": "people", "man": "men", "woman": "women",
+		"mouse": "mice", "goose": "geese", "foot": "feet", "tooth": "teeth", "ox": "oxen",
+		"leaf": "leaves", "life": "lives", "knife": "knives", "wife": "wives",
+		"half": "halves", "wolf": "wolves", "shelf": "shelves", "thief": "thieves",
+		"cactus": "cacti", "criterion": "criteria", "index": "indices", "matrix": "matrices",
+		"photo": "photos", "piano": "pianos", "quiz": "quizzes", "hero": "heroes",
+		"potato": "potatoes", "tomato": "tomatoes",
+		"deer": "deer", "fish": "fish", "sheep": "sheep", "series": "series",
+		"species": "species", "news": "news", "equipment": "equipment",
+		"information": "information", "money": "money", "rice": "rice",
+	}
+)
+
+// Pluralize returns the word if n == 1, and returns the plural of the word if n != 1.
+//
+//	Pluralize(1, "item")  // "item"
+//	Pluralize(3, "item")  // "items"
+//	Pluralize(2, "child") // "children"
+//
+// .
+func Pluralize[T Num](n T, word string) string {
+	if n == T(1) {
+		return word
+	}
+	return PluralOf(word)
+}
+
+// PluralizeCount returns the count followed by the pluralized word.
+//
+//	PluralizeCount(1, "item") // "1 item"
+//	PluralizeCount(3, "item") // "3 items"
+//
+// .
+func PluralizeCount[T Num](n T, word string) string {
+	return fmt.Sprintf("%v %s", n, Pluralize(n, word))
+}
+
+// PluralOf returns the plural of the word, using the configured pluralizer.
+func PluralOf(word string) string {
+	pluralMu.RLock()
+	defer pluralMu.RUnlock()
+	return pluralizer(word)
+}
+
+// RegisterPlural adds an irregular plural to the english pluralizer,
+// like RegisterPlural("cactus", "cacti").
+func RegisterPlural(singular, plural string) {
+	pluralMu.Lock()
+	defer pluralMu.Unlock()
+	irregulars[strings.ToLower(singular)] = strings.ToLower(plural)
+}
+
+// SetPluralizer sets the func which returns the plural of a word, which
+// defaults to the english pluralizer. This allows plurals in other locales.
+// A nil func restores the english pluralizer.
+//
+//	format.SetPluralizer(func(word string) string { return word + "en" })
+//
+// .
+func SetPluralizer(fn func(word string) string) {
+	pluralMu.Lock()
+	defer pluralMu.Unlock()
+
+	if fn == nil {
+		fn = englishPlural
+	}
+	pluralizer = fn
+}
+
+// englishPlural returns the english plural of the word, preserving its case.
+// The caller must hold pluralMu.
+func englishPlural(word string) string {
+	if word == "" {
+		return word
+	}
+
+	lower := strings.ToLower(word)
+	plural, ok := irregulars[lower]
+	if !ok {
+		plural = regularPlural(lower)
+	}
+
+	switch {
+	case word == strings.ToUpper(word):
+		return strings.ToUpper(plural)
+	case word == TitleFirst(lower):
+		return TitleFirst(plural)
+	case strings.HasPrefix(plural, lower):
+		return word + plural[len(lower):]
+	}
+	return plural
+}
+
+// regularPlural returns the plural of a lowercase word, following
+// the regular english spelling rules.
+func regularPlural(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case len(word) > 1 && word[len(word)-1] == 'y' && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Slug Formatters
+//
+//
+// ------------------------------------------------------------------
+
+// transliterations are the ascii replacements of common accented characters.
+var transliterations = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ą': "a",
+	'æ': "ae", 'ç': "c", 'ć': "c", 'č': "c", 'ď': "d", 'đ': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ę': "e", 'ě': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'ł': "l",
+	'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o",
+	'œ': "oe", 'ř': "r", 'ß': "ss", 'ś': "s", 'š': "s", 'ť': "t", 'þ': "th",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'ý': "y", 'ÿ': "y", 'ź': "z", 'ż': "z", 'ž': "z",
+}
+
+// Slugify converts a string into a url slug, like "Héllo, World!" => "hello-world".
+//
+// The string is lowercased, common accented characters are transliterated
+// to ascii, and runs of any other characters are collapsed into a hyphen.
+func Slugify(s string) string {
+	var b strings.Builder
+	hyphen := false
+
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			hyphen = false
+		case transliterations[r] != "":
+			b.WriteString(transliterations[r])
+			hyphen = false
+		case !hyphen && b.Len() > 0:
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// SlugifyMax converts a string into a url slug, like Slugify, which is at most
+// n characters long. The slug is cut at a hyphen, unless its first word is too long.
+func SlugifyMax(s string, n int) string {
+	slug := Slugify(s)
+	if n <= 0 {
+		return ""
+	}
+	if len(slug) <= n {
+		return slug
+	}
+
+	// The cut is at a word boundary.
+	if slug[n] == '-' {
+		return slug[:n]
+	}
+
+	slug = slug[:n]
+	if i := strings.LastIndexByte(slug, '-'); i > 0 {
+		return slug[:i]
+	}
+	return strings.TrimSuffix(slug, "-")
+}
+
+// ------------------------------------------------------------------
+//
+//
+// HTML Formatters
+//
+//
+// ------------------------------------------------------------------
+
+// blockTags are the elements which are separated by a line break in plain text.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "main": true, "nav": true, "ol": true,
+	"p": true, "pre": true, "section": true, "table": true, "tr": true, "ul": true,
+}
+
+// StripTags converts html to plain text, like "<p>Fish &amp; Chips</p>" => "Fish & Chips".
+//
+// Tags and comments are removed, along with the content of script and style
+// elements. Block elements, like paragraphs, are placed on separate lines,
+// entities are decoded and whitespace is collapsed.
+func StripTags(s string) string {
+	var b strings.Builder
+
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			b.WriteString(textSpaces.Replace(s))
+			break
+		}
+		b.WriteString(textSpaces.Replace(s[:i]))
+		s = s[i:]
+
+		// Comments.
+		if strings.HasPrefix(s, "<!--") {
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				break
+			}
+			s = s[end+len("-->"):]
+			continue
+		}
+
+		// A "<" which does not start a tag is text.
+		if len(s) < 2 || !(isAsciiLetter(s[1]) || s[1] == '/' || s[1] == '!') {
+			b.WriteByte('<')
+			s = s[1:]
+			continue
+		}
+
+		end := tagEnd(s)
+		if end < 0 {
+			break
+		}
+		name := tagName(s[:end])
+		s = s[end+1:]
+
+		// Skip the content of raw text elements.
+		if name == "script" || name == "style" {
+			closing := indexFold(s, "</"+name)
+			if closing < 0 {
+				break
+			}
+			s = s[closing:]
+			if end := tagEnd(s); end >= 0 {
+				s = s[end+1:]
+				continue
+			}
+			break
+		}
+
+		if blockTags[name] {
+			b.WriteByte('\n')
+		}
+	}
+
+	return collapseText(html.UnescapeString(b.String()))
+}
+
+// indexFold returns the index of the first ascii case-insensitive match of
+// the lowercase substr in s, or -1. Unlike strings.ToLower, it keeps the
+// byte offsets of s, even when s is not valid utf-8.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		j := 0
+		for j < len(substr) && lowerAscii(s[i+j]) == substr[j] {
+			j++
+		}
+		if j == len(substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// lowerAscii returns the lowercase of an ascii letter, or c unchanged.
+func lowerAscii(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// tagEnd returns the index of the ">" which closes the tag at the start
+// of s, skipping quoted attribute values, or -1 if the tag is not closed.
+func tagEnd(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+// tagName returns the lowercase name of a tag, like "<a href>" => "a".
+func tagName(tag string) string {
+	tag = strings.TrimLeft(tag[1:], "/!")
+	end := strings.IndexFunc(tag, func(r rune) bool {
+		return !isAsciiLetter(byte(r)) && !unicode.IsDigit(r)
+	})
+	if end >= 0 {
+		tag = tag[:end]
+	}
+	return strings.ToLower(tag)
+}
+
+// textSpaces replaces the line breaks of html text, which render as spaces.
+var textSpaces = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// collapseText collapses runs of spaces within lines, trims each line,
+// and removes blank lines.
+func collapseText(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isAsciiLetter checks if the byte is an ascii letter.
+func isAsciiLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
 }
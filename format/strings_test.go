@@ -0,0 +1,180 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Title Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestTitle(t *testing.T) {
+	t.Run("TitleFirst", func(t *testing.T) {
+		assert.Equal(t, TitleFirst(""), "")
+		assert.Equal(t, TitleFirst("a"), "A")
+		assert.Equal(t, TitleFirst("élan vital"), "Élan vital")
+		assert.Equal(t, TitleFirst("ǆungla"), "ǅungla")
+		assert.Equal(t, TitleFirst("über"), "Über")
+		assert.Equal(t, TitleFirst("日本"), "日本")
+		assert.Equal(t, TitleFirst("\xff"), "\xff")
+	})
+
+	t.Run("Title", func(t *testing.T) {
+		assert.Equal(t, Title(""), "")
+		assert.Equal(t, Title("hello world"), "Hello World")
+		assert.Equal(t, Title("  two  spaces "), "  Two  Spaces ")
+		assert.Equal(t, Title("ñandú émile ödön"), "Ñandú Émile Ödön")
+		assert.Equal(t, Title("the lord of the rings"), "The Lord Of The Rings")
+	})
+
+	t.Run("TitleSkip", func(t *testing.T) {
+		assert.Equal(t, TitleSkip("the lord of the rings", SmallWords...), "The Lord of the Rings")
+		assert.Equal(t, TitleSkip("OF mice AND men", SmallWords...), "OF Mice and Men")
+		assert.Equal(t, TitleSkip(" a tale", SmallWords...), " A Tale")
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Plural Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestPluralize(t *testing.T) {
+	t.Run("regular", func(t *testing.T) {
+		tests := map[string]string{
+			"item": "items", "box": "boxes", "bus": "buses", "buzz": "buzzes",
+			"match": "matches", "dish": "dishes", "city": "cities", "day": "days",
+		}
+		for word, want := range tests {
+			assert.Equal(t, Pluralize(2, word), want)
+		}
+	})
+
+	t.Run("irregular", func(t *testing.T) {
+		assert.Equal(t, Pluralize(2, "child"), "children")
+		assert.Equal(t, Pluralize(2, "person"), "people")
+		assert.Equal(t, Pluralize(2, "sheep"), "sheep")
+	})
+
+	t.Run("count", func(t *testing.T) {
+		assert.Equal(t, Pluralize(1, "item"), "item")
+		assert.Equal(t, Pluralize(0, "item"), "items")
+		assert.Equal(t, Pluralize(1.5, "item"), "items")
+		assert.Equal(t, PluralizeCount(1, "item"), "1 item")
+		assert.Equal(t, PluralizeCount(3, "child"), "3 children")
+	})
+
+	t.Run("case", func(t *testing.T) {
+		assert.Equal(t, PluralOf(""), "")
+		assert.Equal(t, PluralOf("Person"), "People")
+		assert.Equal(t, PluralOf("CITY"), "CITIES")
+		assert.Equal(t, PluralOf("iPhone"), "iPhones")
+	})
+
+	t.Run("RegisterPlural", func(t *testing.T) {
+		defer delete(irregulars, "octopus")
+		RegisterPlural("Octopus", "octopodes")
+		assert.Equal(t, PluralOf("octopus"), "octopodes")
+	})
+
+	t.Run("SetPluralizer", func(t *testing.T) {
+		defer SetPluralizer(nil)
+		SetPluralizer(func(word string) string { return word + "en" })
+		assert.Equal(t, Pluralize(2, "kind"), "kinden")
+
+		SetPluralizer(nil)
+		assert.Equal(t, Pluralize(2, "kind"), "kinds")
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Slug Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Hello World":             "hello-world",
+		"  Héllo,  Wörld!!  ":     "hello-world",
+		"Crème Brûlée & Straße":   "creme-brulee-strasse",
+		"Go 1.23 -- released":     "go-1-23-released",
+		"日本語 title":               "title",
+		"---":                     "",
+		"already-a-slug":          "already-a-slug",
+		"Ærøskøbing, Łódź, Škoda": "aeroskobing-lodz-skoda",
+	}
+
+	for s, want := range tests {
+		t.Run(want, func(t *testing.T) {
+			assert.Equal(t, Slugify(s), want)
+		})
+	}
+
+	t.Run("SlugifyMax", func(t *testing.T) {
+		assert.Equal(t, SlugifyMax("The quick brown fox", 12), "the-quick")
+		assert.Equal(t, SlugifyMax("The quick brown fox", 100), "the-quick-brown-fox")
+		assert.Equal(t, SlugifyMax("Supercalifragilistic", 5), "super")
+		assert.Equal(t, SlugifyMax("ab cd", 3), "ab")
+		assert.Equal(t, SlugifyMax("The quick brown fox", 9), "the-quick")
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// HTML Formatters
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestStripTags(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"plain", "no tags", "no tags"},
+		{"entities", "<p>Fish &amp; Chips &lt;3</p>", "Fish & Chips <3"},
+		{"inline", "Hello <b>big</b>   <a href=\"/x?a=1&amp;b>2\">world</a>", "Hello big world"},
+		{"blocks", "<h1>Title</h1><p>One</p>\n\n\n<p>Two<br>Three</p>", "Title\nOne\nTwo\nThree"},
+		{"script-style", "a<script>if (x < y) {}</script>b<style>p{}</style>c", "abc"},
+		{"script-invalid-utf8", "a<script>\xff\xff\xff\xff</SCRIPT>b", "ab"},
+		{"comments", "a<!-- hidden -->b", "ab"},
+		{"less-than", "1 < 2 and 3<4", "1 < 2 and 3<4"},
+		{"unclosed", "text <b", "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, StripTags(tt.html), tt.want)
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tunedmystic/rio/decimal"
+	"github.com/tunedmystic/rio/format"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Struct Binding
+//
+//
+// ------------------------------------------------------------------
+
+// maxMemory is the memory limit when parsing multipart form data.
+const maxMemory = 32 << 20
+
+// Bind parses the request's form data, and cleans it into the fields of dst,
+// which must be a pointer to a struct. Fields are declared with struct tags.
+//
+//	type SignupForm struct {
+//		Name   string    `form:"name" check:"required,gte=3"`
+//		Age    int       `form:"age" check:"required,btw=18|120"`
+//		Email  string    `form:"email" check:"required,email"`
+//		Plan   string    `form:"plan" check:"in=free|pro"`
+//		Joined time.Time `form:"joined" check:"past"`
+//	}
+//
+//	var data SignupForm
+//	form, err := forms.Bind(r, &data)
+//	if err != nil {
+//		return err
+//	}
+//	if !form.IsValid() {
+//		...
+//	}
+//
+// .
+//
+// The `form` tag is the field name, and fields without it are skipped.
+// The type of the struct field decides how the value is cleaned:
+// string, int, float, bool, time.Time, decimal.Decimal or *multipart.FileHeader.
+// A bool is cleaned as a checkbox, see CleanCheckbox.
+//
+// The `check` tag is a comma separated list of checks, which map to the
+// check funcs of the field's type. Lists of arguments are separated by "|".
+//
+//	required            all types
+//	optional            all types, skips the checks if the value is blank
+//	lt, lte, gt, gte    string length, int, float and decimal
+//	btw=n|m             string length, int, float and decimal
+//	in=a|b|c            string, int and float
+//	email, url, slug    string
+//	uuid                string
+//	checked             bool
+//	ip, ipv4, ipv6      string
+//	cidr, hostname      string
+//	before, after       time.Time (yyyy-mm-dd)
+//	past, future        time.Time
+//	maxsize=10MB        *multipart.FileHeader
+//	ext=.png|.jpg       *multipart.FileHeader
+//
+// Valid values are set on dst, and invalid values are left unchanged.
+// An error is returned if the request cannot be parsed, or if dst or
+// its tags are invalid.
+func Bind(r *http.Request, dst any) (*Form, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	if err := r.ParseMultipartForm(maxMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("forms: bind destination must be a pointer to a struct, got %T", dst)
+	}
+	rv = rv.Elem()
+
+	form := New()
+	for i := range rv.NumField() {
+		sf := rv.Type().Field(i)
+		name := sf.Tag.Get("form")
+		if name == "" || name == "-" || !sf.IsExported() {
+			continue
+		}
+
+		kind, err := bindKindOf(sf.Type)
+		if err != nil {
+			return nil, fmt.Errorf("forms: field %s: %w", sf.Name, err)
+		}
+		checks, err := bindChecks(kind, sf.Tag.Get("check"))
+		if err != nil {
+			return nil, fmt.Errorf("forms: field %s: %w", sf.Name, err)
+		}
+
+		if kind == bindFile {
+			var fh *multipart.FileHeader
+			if r.MultipartForm != nil && len(r.MultipartForm.File[name]) > 0 {
+				fh = r.MultipartForm.File[name][0]
+			}
+			form.CleanFile(name, fh, checks...)
+		} else {
+			field := bindFit(rv.Field(i), kind, bindParsers[kind](r.Form.Get(name)))
+			form.cleanField(name, bindKindNames[kind], field, checks...)
+		}
+
+		if field := form.MustField(name); field.Err() == nil && !field.IsBlank() {
+			setBindValue(rv.Field(i), kind, field)
+		}
+	}
+
+	return form, nil
+}
+
+// bindKind is the type of a bound struct field.
+type bindKind int
+
+const (
+	bindString bindKind = iota
+	bindInt
+	bindFloat
+	bindBool
+	bindDate
+	bindDecimal
+	bindFile
+)
+
+// bindParsers are the parse funcs of the bound types.
+var bindParsers = map[bindKind]ParseFunc{
+	bindString:  parseString,
+	bindInt:     parseInteger,
+	bindFloat:   parseFloat,
+	bindBool:    parseCheckbox,
+	bindDate:    parseDate,
+	bindDecimal: parseDecimal,
+}
+
+// bindKindNames are the type names of the bound types, for Form.Report.
+var bindKindNames = map[bindKind]string{
+	bindString:  "string",
+	bindInt:     "integer",
+	bindFloat:   "float",
+	bindBool:    "checkbox",
+	bindDate:    "date",
+	bindDecimal: "decimal",
+	bindFile:    "file",
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	decimalType  = reflect.TypeFor[decimal.Decimal]()
+	fileType     = reflect.TypeFor[*multipart.FileHeader]()
+	errBindCheck = errors.New("unknown check")
+)
+
+// bindKindOf returns the bound type of the struct field type.
+func bindKindOf(t reflect.Type) (bindKind, error) {
+	switch t {
+	case timeType:
+		return bindDate, nil
+	case decimalType:
+		return bindDecimal, nil
+	case fileType:
+		return bindFile, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return bindString, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return bindInt, nil
+	case reflect.Float32, reflect.Float64:
+		return bindFloat, nil
+	case reflect.Bool:
+		return bindBool, nil
+	}
+	return 0, fmt.Errorf("unsupported type %s", t)
+}
+
+// errBindRange is the error of a float which overflows the struct field.
+var errBindRange = ParseError{"is out of range"}
+
+// bindFit adds a parse error to the field if its value overflows the
+// struct field, like 300 for an int8, so that it is not set on dst.
+func bindFit(v reflect.Value, kind bindKind, field Field) Field {
+	if field.err != nil || field.isBlank {
+		return field
+	}
+
+	switch kind {
+	case bindInt:
+		if v.OverflowInt(int64(field.Integer)) {
+			min := int64(-1) << (v.Type().Bits() - 1)
+			field.err = ParseError{fmt.Sprintf(errBetween, min, -(min + 1))}
+		}
+	case bindFloat:
+		if v.OverflowFloat(field.Float) {
+			field.err = errBindRange
+		}
+	}
+	return field
+}
+
+// setBindValue sets the cleaned value of the field on the struct field.
+func setBindValue(v reflect.Value, kind bindKind, field Field) {
+	switch kind {
+	case bindString:
+		v.SetString(field.String)
+	case bindInt:
+		v.SetInt(int64(field.Integer))
+	case bindFloat:
+		v.SetFloat(field.Float)
+	case bindBool:
+		v.SetBool(field.Bool)
+	case bindDate:
+		v.Set(reflect.ValueOf(field.Date))
+	case bindDecimal:
+		v.Set(reflect.ValueOf(field.Decimal))
+	case bindFile:
+		v.Set(reflect.ValueOf(field.File))
+	}
+}
+
+// bindChecks parses the check tag into the check funcs of the bound type.
+func bindChecks(kind bindKind, tag string) ([]CheckFunc, error) {
+	var checks []CheckFunc
+	optional := false
+
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if part == "optional" {
+			optional = true
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+
+		check, err := bindCheck(kind, name, arg)
+		if err != nil {
+			return nil, fmt.Errorf("check %q: %w", part, err)
+		}
+		checks = append(checks, check)
+	}
+
+	if optional {
+		return []CheckFunc{Optional(checks...)}, nil
+	}
+	return checks, nil
+}
+
+// bindCheck returns the check func of the bound type, with the argument.
+func bindCheck(kind bindKind, name, arg string) (CheckFunc, error) {
+	if name == "required" {
+		return StrRequired(), nil
+	}
+	args := strings.Split(arg, "|")
+
+	switch kind {
+	case bindString:
+		switch name {
+		case "email":
+			return StrEmail(), nil
+		case "url":
+			return StrUrl(), nil
+		case "slug":
+			return StrSlug(), nil
+		case "uuid":
+			return StrUUID(), nil
+		case "ip":
+			return StrIP(), nil
+		case "ipv4":
+			return StrIPv4(), nil
+		case "ipv6":
+			return StrIPv6(), nil
+		case "cidr":
+			return StrCIDR(), nil
+		case "hostname":
+			return StrHostname(), nil
+		case "in":
+			return StrIn(args), nil
+		}
+		return bindNumberCheck(name, args, strconv.Atoi, StrLt, StrLte, StrGt, StrGte, StrBtw)
+
+	case bindInt:
+		if name == "in" {
+			return bindInCheck(args, strconv.Atoi, IntIn)
+		}
+		return bindNumberCheck(name, args, strconv.Atoi, IntLt, IntLte, IntGt, IntGte, IntBtw)
+
+	case bindFloat:
+		parse := func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }
+		if name == "in" {
+			return bindInCheck(args, parse, FltIn)
+		}
+		return bindNumberCheck(name, args, parse, FltLt, FltLte, FltGt, FltGte, FltBtw)
+
+	case bindDecimal:
+		parse := func(s string) (string, error) { return s, nil }
+		return bindNumberCheck(name, args, parse, DecLt, DecLte, DecGt, DecGte, DecBtw)
+
+	case bindBool:
+		if name == "checked" {
+			return BoolMustBeTrue(), nil
+		}
+
+	case bindDate:
+		switch name {
+		case "before":
+			return DtBefore(arg), nil
+		case "after":
+			return DtAfter(arg), nil
+		case "past":
+			return DtInPast(), nil
+		case "future":
+			return DtInFuture(), nil
+		}
+
+	case bindFile:
+		switch name {
+		case "maxsize":
+			n, err := format.ParseBytes(arg)
+			if err != nil {
+				return nil, err
+			}
+			return FileMaxSize(n), nil
+		case "ext":
+			return FileExt(args), nil
+		}
+	}
+
+	return nil, errBindCheck
+}
+
+// bindNumberCheck returns the comparison check func with the parsed arguments.
+func bindNumberCheck[T any](
+	name string,
+	args []string,
+	parse func(string) (T, error),
+	lt, lte, gt, gte func(T) CheckFunc,
+	btw func(T, T) CheckFunc,
+) (CheckFunc, error) {
+	checks := map[string]func(T) CheckFunc{"lt": lt, "lte": lte, "gt": gt, "gte": gte}
+
+	if name == "btw" {
+		if len(args) != 2 {
+			return nil, errors.New("needs 2 arguments")
+		}
+		n, err := parse(args[0])
+		if err != nil {
+			return nil, err
+		}
+		m, err := parse(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return btw(n, m), nil
+	}
+
+	check, ok := checks[name]
+	if !ok {
+		return nil, errBindCheck
+	}
+	n, err := parse(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return check(n), nil
+}
+
+// bindInCheck returns the choices check func with the parsed arguments.
+func bindInCheck[T any](args []string, parse func(string) (T, error), in func([]T) CheckFunc) (CheckFunc, error) {
+	choices := make([]T, len(args))
+	for i := range args {
+		c, err := parse(args[i])
+		if err != nil {
+			return nil, err
+		}
+		choices[i] = c
+	}
+	return in(choices), nil
+}
@@ -0,0 +1,202 @@
+package forms
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tunedmystic/rio/assert"
+	"github.com/tunedmystic/rio/decimal"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Struct Binding
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestBind(t *testing.T) {
+	type signup struct {
+		Name    string          `form:"name" check:"required,gte=3"`
+		Age     int             `form:"age" check:"required,btw=18|120"`
+		Email   string          `form:"email" check:"required,email"`
+		Plan    string          `form:"plan" check:"in=free|pro"`
+		Score   float64         `form:"score" check:"lte=10"`
+		Price   decimal.Decimal `form:"price" check:"gt=0"`
+		Joined  time.Time       `form:"joined" check:"before=2030-01-01"`
+		Agreed  bool            `form:"agreed" check:"required"`
+		Ignored string
+	}
+
+	newRequest := func(values url.Values) *http.Request {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		r := newRequest(url.Values{
+			"name":   {"  Alice  "},
+			"age":    {"30"},
+			"email":  {"alice@example.com"},
+			"plan":   {"pro"},
+			"score":  {"9.5"},
+			"price":  {"19.99"},
+			"joined": {"2024-08-15"},
+			"agreed": {"true"},
+		})
+
+		data := signup{Ignored: "keep"}
+		form, err := Bind(r, &data)
+		assert.Nil(t, err)
+		assert.Equal(t, form.IsValid(), true)
+
+		assert.Equal(t, data.Name, "Alice")
+		assert.Equal(t, data.Age, 30)
+		assert.Equal(t, data.Plan, "pro")
+		assert.Equal(t, data.Score, 9.5)
+		assert.Equal(t, data.Price.String(), "19.99")
+		assert.Equal(t, data.Joined, time.Date(2024, 8, 15, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, data.Agreed, true)
+		assert.Equal(t, data.Ignored, "keep")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := newRequest(url.Values{
+			"name":  {"Al"},
+			"age":   {"12"},
+			"email": {"alice"},
+			"plan":  {"gold"},
+			"price": {"-1"},
+		})
+
+		var data signup
+		form, err := Bind(r, &data)
+		assert.Nil(t, err)
+		assert.Equal(t, form.IsValid(), false)
+		assert.Equal(t, data.Name, "")
+		assert.Equal(t, data.Age, 0)
+
+		for name, want := range map[string]string{
+			"name":   "must be more than or equal to 3 characters",
+			"age":    "must be between 18 and 120",
+			"email":  "must be a valid email",
+			"plan":   "must be a valid choice",
+			"price":  "must be more than 0.00",
+			"agreed": "cannot be blank",
+		} {
+			assert.Equal(t, form.MustField(name).Err().Error(), want)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		type upload struct {
+			Avatar *multipart.FileHeader `form:"avatar" check:"required,maxsize=1KB,ext=.png"`
+		}
+
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile("avatar", "me.png")
+		fw.Write([]byte("png"))
+		mw.Close()
+
+		r := httptest.NewRequest("POST", "/", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+
+		var data upload
+		form, err := Bind(r, &data)
+		assert.Nil(t, err)
+		assert.Equal(t, form.IsValid(), true)
+		assert.Equal(t, data.Avatar.Filename, "me.png")
+	})
+
+	t.Run("invalid destination", func(t *testing.T) {
+		var data signup
+		_, err := Bind(newRequest(nil), data)
+		assert.NotNil(t, err)
+
+		var unsupported struct {
+			Tags []string `form:"tags"`
+		}
+		_, err = Bind(newRequest(nil), &unsupported)
+		assert.Contains(t, err.Error(), "unsupported type []string")
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		var data struct {
+			Age  int `form:"age" check:"optional,gte=18"`
+			Size int `form:"size" check:"optional,gte=18"`
+		}
+		form, err := Bind(newRequest(url.Values{"size": {"3"}}), &data)
+		assert.Nil(t, err)
+		assert.Nil(t, form.MustField("age").Err())
+		assert.NotNil(t, form.MustField("size").Err())
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		var data struct {
+			Age   int8    `form:"age" check:"gte=0"`
+			Small int16   `form:"small"`
+			Ratio float32 `form:"ratio"`
+		}
+		values := url.Values{"age": {"300"}, "small": {"-300"}, "ratio": {"1e300"}}
+		form, err := Bind(newRequest(values), &data)
+		assert.Nil(t, err)
+		assert.Equal(t, form.IsValid(), false)
+		assert.Equal(t, form.FieldError("age"), "must be between -128 and 127")
+		assert.Equal(t, form.FieldError("small"), "")
+		assert.Equal(t, form.FieldError("ratio"), "is out of range")
+		assert.Equal(t, data.Age, int8(0))
+		assert.Equal(t, data.Small, int16(-300))
+		assert.Equal(t, data.Ratio, float32(0))
+	})
+
+	t.Run("checkbox", func(t *testing.T) {
+		var data struct {
+			Terms      bool `form:"terms" check:"checked"`
+			Newsletter bool `form:"newsletter" check:"checked"`
+		}
+		form, err := Bind(newRequest(url.Values{"terms": {"on"}}), &data)
+		assert.Nil(t, err)
+		assert.Equal(t, data.Terms, true)
+		assert.Equal(t, form.FieldError("newsletter"), "must be checked")
+	})
+
+	t.Run("network", func(t *testing.T) {
+		var data struct {
+			Host   string `form:"host" check:"hostname"`
+			Addr   string `form:"addr" check:"ipv4"`
+			Subnet string `form:"subnet" check:"cidr"`
+		}
+		form, err := Bind(newRequest(url.Values{
+			"host":   {"db-1.internal"},
+			"addr":   {"2001:db8::1"},
+			"subnet": {"10.0.0.0/8"},
+		}), &data)
+		assert.Nil(t, err)
+		assert.Equal(t, data.Host, "db-1.internal")
+		assert.Equal(t, data.Subnet, "10.0.0.0/8")
+		assert.Equal(t, form.MustField("addr").Err().Error(), "must be a valid ipv4 address")
+	})
+
+	t.Run("invalid checks", func(t *testing.T) {
+		for _, tag := range []string{"unknown", "gte=abc", "btw=1", "past"} {
+			_, err := bindChecks(bindInt, tag)
+			assert.NotNil(t, err)
+		}
+
+		_, err := bindChecks(bindFile, "maxsize=lots")
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,180 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Card Check Functions
+//
+//
+// ------------------------------------------------------------------
+
+// Brand is a payment card brand, which is detected from the card number.
+type Brand int
+
+const (
+	Visa Brand = iota
+	Mastercard
+	Amex
+	Discover
+	DinersClub
+	JCB
+	UnionPay
+)
+
+// String returns the name of the brand, like "Visa".
+func (b Brand) String() string {
+	switch b {
+	case Visa:
+		return "Visa"
+	case Mastercard:
+		return "Mastercard"
+	case Amex:
+		return "American Express"
+	case Discover:
+		return "Discover"
+	case DinersClub:
+		return "Diners Club"
+	case JCB:
+		return "JCB"
+	case UnionPay:
+		return "UnionPay"
+	}
+	return "Unknown"
+}
+
+// cardBrand holds the number prefix ranges and lengths of a brand.
+type cardBrand struct {
+	brand   Brand
+	ranges  [][2]int // Inclusive ranges of the number prefix, with the same number of digits.
+	lengths []int
+}
+
+// cardBrands are the brands, in the order they are detected.
+var cardBrands = []cardBrand{
+	{Amex, [][2]int{{34, 34}, {37, 37}}, []int{15}},
+	{DinersClub, [][2]int{{300, 305}, {36, 36}, {38, 39}}, []int{14, 15, 16, 17, 18, 19}},
+	{JCB, [][2]int{{3528, 3589}}, []int{16, 17, 18, 19}},
+	{Discover, [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, []int{16, 17, 18, 19}},
+	{UnionPay, [][2]int{{62, 62}}, []int{16, 17, 18, 19}},
+	{Mastercard, [][2]int{{51, 55}, {2221, 2720}}, []int{16}},
+	{Visa, [][2]int{{4, 4}}, []int{13, 16, 19}},
+}
+
+var (
+	errInvalidLuhn = errors.New("must be a valid number")
+	errInvalidCard = errors.New("must be a valid card number")
+)
+
+// Checks that a string of digits passes the Luhn checksum,
+// which is used by card numbers and other identification numbers.
+// Spaces and dashes are ignored.
+func StrLuhn() CheckFunc {
+	return func(v Field) error {
+		if !isLuhn(cardDigits(v.String)) {
+			return errInvalidLuhn
+		}
+		return nil
+	}
+}
+
+// Checks that a string is a card number of one of the brands,
+// or of any known brand if none are given. Spaces and dashes are ignored.
+//
+//	forms.StrCreditCard()
+//	forms.StrCreditCard(forms.Visa, forms.Mastercard)
+//
+// .
+func StrCreditCard(brands ...Brand) CheckFunc {
+	names := make([]string, len(brands))
+	for i := range brands {
+		names[i] = brands[i].String()
+	}
+	errBrand := fmt.Errorf("must be a %s card", strings.Join(names, " or "))
+
+	return func(v Field) error {
+		brand, ok := cardBrandOf(cardDigits(v.String))
+		if !ok {
+			return errInvalidCard
+		}
+		if len(brands) > 0 && !slices.Contains(brands, brand) {
+			return errBrand
+		}
+		return nil
+	}
+}
+
+// cardBrandOf returns the brand of the card number, which must pass the Luhn checksum.
+func cardBrandOf(digits string) (Brand, bool) {
+	if !isLuhn(digits) {
+		return 0, false
+	}
+
+	for _, cb := range cardBrands {
+		if !slices.Contains(cb.lengths, len(digits)) {
+			continue
+		}
+		for _, r := range cb.ranges {
+			prefix, _ := strconv.Atoi(digits[:len(strconv.Itoa(r[0]))])
+			if r[0] <= prefix && prefix <= r[1] {
+				return cb.brand, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// cardDigits removes the spaces and dashes from the card number.
+func cardDigits(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+// isLuhn checks if the string of digits passes the Luhn checksum.
+func isLuhn(digits string) bool {
+	if len(digits) < 2 || !isDigits(digits) {
+		return false
+	}
+
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// Mask returns the field's string with all but the last n characters
+// replaced by "*". Spaces and dashes are kept, so that grouping is preserved.
+//
+//	field.Mask(4) // "**** **** **** 4242"
+//
+// .
+func (f Field) Mask(n int) string {
+	runes := []rune(f.String)
+	visible := 0
+
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == ' ' || runes[i] == '-' {
+			continue
+		}
+		if visible < n {
+			visible++
+			continue
+		}
+		runes[i] = '*'
+	}
+	return string(runes)
+}
@@ -0,0 +1,84 @@
+package forms
+
+import (
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Card CheckFuncs
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestCardCheckFuncs(t *testing.T) {
+	t.Run("StrLuhn", func(t *testing.T) {
+		for _, s := range []string{"79927398713", "4242 4242 4242 4242", "0000-0000"} {
+			assert.Equal(t, StrLuhn()(parseString(s)), nil)
+		}
+		for _, s := range []string{"", "7", "79927398710", "4242 4242 4242 4241", "4242x4242"} {
+			assert.Equal(t, StrLuhn()(parseString(s)), errInvalidLuhn)
+		}
+	})
+
+	t.Run("cardBrandOf", func(t *testing.T) {
+		tests := map[string]Brand{
+			"4242424242424242":    Visa,
+			"4222222222222":       Visa,
+			"5555555555554444":    Mastercard,
+			"2223003122003222":    Mastercard,
+			"378282246310005":     Amex,
+			"6011111111111117":    Discover,
+			"30569309025904":      DinersClub,
+			"3566002020360505":    JCB,
+			"6200000000000005":    UnionPay,
+			"6205500000000000004": UnionPay,
+		}
+		for number, want := range tests {
+			brand, ok := cardBrandOf(number)
+			assert.Equal(t, ok, true)
+			assert.Equal(t, brand, want)
+		}
+
+		// Passes Luhn, but has no brand.
+		_, ok := cardBrandOf("1234567812345670")
+		assert.Equal(t, ok, false)
+	})
+
+	t.Run("StrCreditCard", func(t *testing.T) {
+		// ok
+		err1 := StrCreditCard()(parseString("4242 4242 4242 4242"))
+		assert.Equal(t, err1, nil)
+
+		// ok
+		err2 := StrCreditCard(Visa, Mastercard)(parseString("5555-5555-5555-4444"))
+		assert.Equal(t, err2, nil)
+
+		// error, brand
+		err3 := StrCreditCard(Visa, Mastercard)(parseString("378282246310005"))
+		assert.Equal(t, err3.Error(), "must be a Visa or Mastercard card")
+
+		// error, checksum
+		err4 := StrCreditCard()(parseString("4242 4242 4242 4241"))
+		assert.Equal(t, err4.Error(), "must be a valid card number")
+
+		// error, length
+		err5 := StrCreditCard()(parseString("42424242424242"))
+		assert.Equal(t, err5.Error(), "must be a valid card number")
+	})
+
+	t.Run("Mask", func(t *testing.T) {
+		assert.Equal(t, parseString("4242 4242 4242 4242").Mask(4), "**** **** **** 4242")
+		assert.Equal(t, parseString("378282246310005").Mask(4), "***********0005")
+		assert.Equal(t, parseString("12-34").Mask(0), "**-**")
+		assert.Equal(t, parseString("42").Mask(4), "42")
+		assert.Equal(t, parseString("").Mask(4), "")
+	})
+}
@@ -0,0 +1,135 @@
+package forms
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Choices
+//
+//
+// ------------------------------------------------------------------
+
+// Choice is a value and its display label.
+type Choice[T comparable] struct {
+	Value T
+	Label string
+}
+
+// Choices is an ordered list of values and their labels, which
+// validates a field and renders the options of a select element,
+// so that validation and rendering are kept in sync.
+//
+//	var plans = forms.Choices[string]{
+//		{"free", "Free"},
+//		{"pro", "Professional"},
+//	}
+//
+//	form.CleanString("plan", r.FormValue("plan"), plans.CheckFunc())
+//
+// .
+type Choices[T comparable] []Choice[T]
+
+// Values returns the values of the choices.
+func (c Choices[T]) Values() []T {
+	values := make([]T, len(c))
+	for i := range c {
+		values[i] = c[i].Value
+	}
+	return values
+}
+
+// Contains checks if the value is one of the choices.
+func (c Choices[T]) Contains(value T) bool {
+	return slices.ContainsFunc(c, func(ch Choice[T]) bool { return ch.Value == value })
+}
+
+// Label returns the label of the value, or false if the value is not one of the choices.
+func (c Choices[T]) Label(value T) (string, bool) {
+	for i := range c {
+		if c[i].Value == value {
+			return c[i].Label, true
+		}
+	}
+	return "", false
+}
+
+// Check returns a Check which validates that a value is one of the choices.
+// It is used with Clean.
+func (c Choices[T]) Check() Check[T] {
+	return func(v TypedField[T]) error {
+		if !c.Contains(v.Get()) {
+			return errInvalidChoice
+		}
+		return nil
+	}
+}
+
+// CheckFunc returns a CheckFunc which validates that a field is one of the choices.
+// The member of the field is chosen by T, which is a string, int, int64, uint64,
+// float64 or bool. Other types return an invalid config error.
+func (c Choices[T]) CheckFunc() CheckFunc {
+	return func(v Field) error {
+		value, ok := choiceValue[T](v)
+		if !ok {
+			return errInvalidConfig
+		}
+		if !c.Contains(value) {
+			return errInvalidChoice
+		}
+		return nil
+	}
+}
+
+// choiceValue returns the member of the field of type T.
+func choiceValue[T comparable](v Field) (T, bool) {
+	var value any
+	switch any(*new(T)).(type) {
+	case string:
+		value = v.String
+	case int:
+		value = v.Integer
+	case int64:
+		value = v.Int64
+	case uint64:
+		value = v.Uint
+	case float64:
+		value = v.Float
+	case bool:
+		value = v.Bool
+	}
+	t, ok := value.(T)
+	return t, ok
+}
+
+// Option is a choice rendered as an option of a select element.
+type Option struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+// Options returns the choices as options of a select element,
+// where the choices with the selected values are selected.
+//
+//	<select name="plan">
+//	{{ range .Plans.Options .Plan }}
+//		<option value="{{ .Value }}" {{ if .Selected }}selected{{ end }}>{{ .Label }}</option>
+//	{{ end }}
+//	</select>
+//
+// .
+func (c Choices[T]) Options(selected ...T) []Option {
+	options := make([]Option, len(c))
+	for i := range c {
+		options[i] = Option{
+			Value:    fmt.Sprint(c[i].Value),
+			Label:    c[i].Label,
+			Selected: slices.Contains(selected, c[i].Value),
+		}
+	}
+	return options
+}
@@ -0,0 +1,92 @@
+package forms
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Type: Choices
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestChoices(t *testing.T) {
+	plans := Choices[string]{
+		{"free", "Free"},
+		{"pro", "Professional"},
+	}
+	sizes := Choices[int]{
+		{1, "Small"},
+		{2, "Medium"},
+		{3, "Large"},
+	}
+
+	t.Run("Values", func(t *testing.T) {
+		assert.Equal(t, plans.Values(), []string{"free", "pro"})
+		assert.Equal(t, sizes.Values(), []int{1, 2, 3})
+	})
+
+	t.Run("Label", func(t *testing.T) {
+		label, ok := plans.Label("pro")
+		assert.Equal(t, label, "Professional")
+		assert.Equal(t, ok, true)
+
+		_, ok = plans.Label("gold")
+		assert.Equal(t, ok, false)
+	})
+
+	t.Run("CheckFunc", func(t *testing.T) {
+		form := New()
+		form.CleanString("plan", "pro", plans.CheckFunc())
+		form.CleanString("plan2", "gold", plans.CheckFunc())
+		form.CleanInteger("size", "2", sizes.CheckFunc())
+		form.CleanInteger("size2", "4", sizes.CheckFunc())
+
+		assert.Nil(t, form.MustField("plan").Err())
+		assert.Equal(t, form.MustField("plan2").Err(), errInvalidChoice)
+		assert.Nil(t, form.MustField("size").Err())
+		assert.Equal(t, form.MustField("size2").Err(), errInvalidChoice)
+
+		// Unsupported type.
+		type level int
+		levels := Choices[level]{{1, "One"}}
+		assert.Equal(t, levels.CheckFunc()(parseInteger("1")), errInvalidConfig)
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		form := New()
+		Clean(form, "size", "3", strconv.Atoi, sizes.Check())
+		Clean(form, "size2", "9", strconv.Atoi, sizes.Check())
+
+		assert.Equal(t, Cleaned[int](form, "size"), 3)
+		assert.Equal(t, form.MustField("size2").Err(), errInvalidChoice)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		assert.Equal(t, sizes.Options(2), []Option{
+			{"1", "Small", false},
+			{"2", "Medium", true},
+			{"3", "Large", false},
+		})
+
+		tmpl := template.Must(template.New("").Parse(
+			`{{ range .Plans.Options .Plan }}<option value="{{ .Value }}"{{ if .Selected }} selected{{ end }}>{{ .Label }}</option>{{ end }}`,
+		))
+
+		var b strings.Builder
+		err := tmpl.Execute(&b, map[string]any{"Plans": plans, "Plan": "pro"})
+		assert.Nil(t, err)
+		assert.Equal(t, b.String(), `<option value="free">Free</option><option value="pro" selected>Professional</option>`)
+	})
+}
@@ -0,0 +1,74 @@
+package forms
+
+import "context"
+
+// ------------------------------------------------------------------
+//
+//
+// Context Cleaners
+//
+//
+// ------------------------------------------------------------------
+
+// CheckFuncCtx is a function which validates a Field with a context.
+// It is used for checks which do I/O, like a database lookup, so that
+// they respect the cancellation and deadline of the request.
+type CheckFuncCtx func(ctx context.Context, v Field) error
+
+// CheckCtx adapts the checks into a CheckFuncCtx, which ignores the context,
+// so that they can be mixed with context-aware checks.
+//
+//	form.CleanStringCtx(ctx, "username", r.FormValue("username"),
+//		forms.CheckCtx(forms.StrRequired(), forms.StrSlug()),
+//		usernameAvailable(db),
+//	)
+//
+// .
+func CheckCtx(checks ...CheckFunc) CheckFuncCtx {
+	check := And(checks...)
+
+	return func(ctx context.Context, v Field) error {
+		return check(v)
+	}
+}
+
+// CleanStringCtx cleans the given value as a string, with context-aware checks.
+func (f *Form) CleanStringCtx(ctx context.Context, name, value string, funcs ...CheckFuncCtx) {
+	f.cleanFieldCtx(ctx, name, "string", parseString(value), funcs...)
+}
+
+// CleanIntegerCtx cleans the given value as an integer, with context-aware checks.
+func (f *Form) CleanIntegerCtx(ctx context.Context, name, value string, funcs ...CheckFuncCtx) {
+	f.cleanFieldCtx(ctx, name, "integer", parseInteger(value), funcs...)
+}
+
+// CleanInt64Ctx cleans the given value as a 64-bit integer, with context-aware checks.
+func (f *Form) CleanInt64Ctx(ctx context.Context, name, value string, funcs ...CheckFuncCtx) {
+	f.cleanFieldCtx(ctx, name, "int64", parseInt64(value), funcs...)
+}
+
+// CleanUintCtx cleans the given value as an unsigned 64-bit integer, with context-aware checks.
+func (f *Form) CleanUintCtx(ctx context.Context, name, value string, funcs ...CheckFuncCtx) {
+	f.cleanFieldCtx(ctx, name, "uint", parseUint(value), funcs...)
+}
+
+// CleanUUIDCtx cleans the given value as a uuid, with context-aware checks.
+func (f *Form) CleanUUIDCtx(ctx context.Context, name, value string, funcs ...CheckFuncCtx) {
+	f.cleanFieldCtx(ctx, name, "uuid", parseUUID(value), funcs...)
+}
+
+// cleanFieldCtx is the cleaning workflow of cleanField, with context-aware checks.
+// If the context is done before a check is run, the context's error is the
+// field's error, so the form is not valid.
+func (f *Form) cleanFieldCtx(ctx context.Context, name, kind string, field Field, checks ...CheckFuncCtx) {
+	funcs := make([]CheckFunc, len(checks))
+	for i := range checks {
+		funcs[i] = func(v Field) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return checks[i](ctx, v)
+		}
+	}
+	f.checkField(name, kind, field, funcs, checkNames(checks))
+}
@@ -0,0 +1,76 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Context Cleaners
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestCleanCtx(t *testing.T) {
+	errTaken := errors.New("is already taken")
+
+	taken := func(ctx context.Context, v Field) error {
+		if v.String == "admin" || v.Integer == 1 || v.Int64 == 1 || v.Uint == 1 {
+			return errTaken
+		}
+		return nil
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		form := New()
+		form.CleanStringCtx(ctx, "username", "alice", CheckCtx(StrRequired(), StrSlug()), taken)
+		form.CleanIntegerCtx(ctx, "id", "2", taken)
+		form.CleanInt64Ctx(ctx, "id64", "2", taken)
+		form.CleanUintCtx(ctx, "uid", "2", taken)
+		form.CleanUUIDCtx(ctx, "uuid", "F47AC10B-58CC-4372-A567-0E02B2C3D479", taken)
+
+		assert.Equal(t, form.IsValid(), true)
+		assert.Equal(t, form.CleanedString("username"), "alice")
+		assert.Equal(t, form.CleanedUUID("uuid"), "f47ac10b-58cc-4372-a567-0e02b2c3d479")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := context.Background()
+		form := New()
+		form.CleanStringCtx(ctx, "username", "admin", CheckCtx(StrRequired()), taken)
+		form.CleanStringCtx(ctx, "blank", "", CheckCtx(StrRequired()), taken)
+		form.CleanIntegerCtx(ctx, "id", "1", taken)
+		form.CleanIntegerCtx(ctx, "bad", "one", taken)
+
+		assert.Equal(t, form.MustField("username").Err(), errTaken)
+		assert.Equal(t, form.MustField("blank").Err(), errBlankValue)
+		assert.Equal(t, form.MustField("id").Err(), errTaken)
+		assert.Equal(t, form.MustField("bad").Err(), error(errParseInt))
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		called := false
+		form := New()
+		form.CleanStringCtx(ctx, "username", "alice", func(ctx context.Context, v Field) error {
+			called = true
+			return nil
+		})
+
+		assert.Equal(t, called, false)
+		assert.ErrorIs(t, form.MustField("username").Err(), context.Canceled)
+		assert.Equal(t, form.IsValid(), false)
+	})
+}
@@ -0,0 +1,106 @@
+package forms
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Custom Types
+//
+//
+// ------------------------------------------------------------------
+
+// CleanCustom cleans the given value with the parse func, for types which
+// are not built in. A blank value is not parsed. The parse func sets the
+// parsed value with WithValue, or returns InvalidField if the value is invalid.
+//
+//	func parseColor(s string) forms.Field {
+//		c, err := colors.ParseHex(s)
+//		if err != nil {
+//			return forms.InvalidField("must be a valid color")
+//		}
+//		return forms.Field{}.WithValue(c)
+//	}
+//
+//	form.CleanCustom("color", r.FormValue("color"), parseColor)
+//	color := forms.Cleaned[colors.Color](form, "color")
+//
+// .
+func (f *Form) CleanCustom(name, value string, parse ParseFunc, funcs ...CheckFunc) {
+	f.cleanField(name, "custom", parseCustom(value, parse), funcs...)
+}
+
+// CleanType cleans the given value with the parse func which is registered
+// with the type name. It panics if the type is not registered.
+//
+//	forms.RegisterType("color", parseColor)
+//
+//	form.CleanType("color", "background", r.FormValue("background"))
+//
+// .
+func (f *Form) CleanType(typ, name, value string, funcs ...CheckFunc) {
+	typesMu.RLock()
+	parse, ok := types[typ]
+	typesMu.RUnlock()
+
+	if !ok {
+		panic(fmt.Sprintf("forms: type %s is not registered", typ))
+	}
+	f.cleanField(name, typ, parseCustom(value, parse), funcs...)
+}
+
+// parseCustom parses the value with the parse func, and sets the original value on the Field.
+func parseCustom(val string, parse ParseFunc) Field {
+	if val == "" {
+		return Field{val: val, isBlank: true}
+	}
+
+	field := parse(val)
+	field.val = val
+	field.isBlank = false
+	return field
+}
+
+// InvalidField returns a Field with a parse error of the message,
+// for a custom parse func to report an invalid value.
+func InvalidField(msg string) Field {
+	return Field{err: ParseError{msg}}
+}
+
+// WithValue returns a copy of the field with the parsed value of a custom type,
+// which is retrieved with Cleaned.
+func (f Field) WithValue(v any) Field {
+	f.typed = v
+	return f
+}
+
+var (
+	typesMu sync.RWMutex
+	types   = map[string]ParseFunc{
+		"string":   parseString,
+		"integer":  parseInteger,
+		"int64":    parseInt64,
+		"uint":     parseUint,
+		"float":    parseFloat,
+		"bool":     parseBool,
+		"date":     parseDate,
+		"datetime": parseDateTime,
+		"time":     parseTime,
+		"decimal":  parseDecimal,
+		"bytes":    parseBytes,
+		"duration": parseDuration,
+		"uuid":     parseUUID,
+	}
+)
+
+// RegisterType registers the parse func with the type name, for use with
+// CleanType. A registered type replaces the type with the same name.
+// The built in types are registered by their name, like "integer" or "uuid".
+func RegisterType(typ string, parse ParseFunc) {
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	types[typ] = parse
+}
@@ -0,0 +1,64 @@
+package forms
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Custom Types
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+type testColor struct {
+	R, G, B uint8
+}
+
+func parseTestColor(s string) Field {
+	hex, ok := strings.CutPrefix(s, "#")
+	if !ok || len(hex) != 6 {
+		return InvalidField("must be a valid color")
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return InvalidField("must be a valid color")
+	}
+	return Field{}.WithValue(testColor{uint8(n >> 16), uint8(n >> 8), uint8(n)})
+}
+
+func TestCustomTypes(t *testing.T) {
+	t.Run("CleanCustom", func(t *testing.T) {
+		form := New()
+		form.CleanCustom("color", "#ff8000", parseTestColor, StrRequired())
+		form.CleanCustom("bad", "orange", parseTestColor)
+		form.CleanCustom("blank", "", parseTestColor, StrRequired())
+
+		assert.Equal(t, Cleaned[testColor](form, "color"), testColor{255, 128, 0})
+		assert.Equal(t, form.DisplayValue("color"), "#ff8000")
+		assert.Equal(t, form.FieldError("bad"), "must be a valid color")
+		assert.Equal(t, form.DisplayValue("bad"), "orange")
+		assert.Equal(t, form.FieldError("blank"), "cannot be blank")
+	})
+
+	t.Run("CleanType", func(t *testing.T) {
+		RegisterType("test-color", parseTestColor)
+
+		form := New()
+		form.CleanType("test-color", "color", "#000010")
+		form.CleanType("integer", "age", "30", IntGte(18))
+
+		assert.Equal(t, Cleaned[testColor](form, "color"), testColor{0, 0, 16})
+		assert.Equal(t, form.CleanedInteger("age"), 30)
+		assert.Panic(t, func() { form.CleanType("unknown", "x", "1") })
+	})
+}
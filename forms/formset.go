@@ -0,0 +1,191 @@
+package forms
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tunedmystic/rio/format"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: FormSet
+//
+//
+// ------------------------------------------------------------------
+
+// FormSet is a type which parses and validates repeated groups of fields,
+// like the rows of an order, which are named with an index:
+//
+//	items[0].name=Apple&items[0].qty=3&items[1].name=Pear&items[1].qty=1
+//
+// .
+//
+// Each row is cleaned into its own Form, so errors are reported per row.
+type FormSet struct {
+	prefix  string
+	min     int
+	max     int
+	forms   []*Form
+	indexes []int
+	errs    []error
+}
+
+// NewFormSet constructs and returns a FormSet of the rows with the prefix,
+// which must have between min and max rows. A max of 0 has no limit.
+func NewFormSet(prefix string, min, max int) *FormSet {
+	return &FormSet{prefix: prefix, min: min, max: max}
+}
+
+// Clean cleans each row of the values with the func, which is given
+// the row's Form, and a func to get the row's values by field name.
+// Rows where every value is empty are skipped, so that blank rows
+// can be rendered for the user to fill in.
+//
+//	items := forms.NewFormSet("items", 1, 10)
+//	items.Clean(r.PostForm, func(f *forms.Form, get func(string) string) {
+//		f.CleanString("name", get("name"), forms.StrRequired())
+//		f.CleanInteger("qty", get("qty"), forms.IntGte(1))
+//	})
+//
+//	if !items.IsValid() {
+//		...
+//	}
+//
+// .
+func (s *FormSet) Clean(values url.Values, fn func(f *Form, get func(name string) string)) {
+	s.forms, s.indexes, s.errs = nil, nil, nil
+	rows := s.rows(values)
+
+	for _, index := range slices.Sorted(maps.Keys(rows)) {
+		row := rows[index]
+		if isBlankRow(row) {
+			continue
+		}
+
+		form := New()
+		fn(form, func(name string) string { return row[name] })
+		s.forms = append(s.forms, form)
+		s.indexes = append(s.indexes, index)
+	}
+
+	if len(s.forms) < s.min {
+		s.errs = append(s.errs, fmt.Errorf("must have at least %s", format.PluralizeCount(s.min, "row")))
+	}
+	if s.max > 0 && len(s.forms) > s.max {
+		s.errs = append(s.errs, fmt.Errorf("must have at most %s", format.PluralizeCount(s.max, "row")))
+	}
+}
+
+// rows groups the values of the formset by row index and field name.
+func (s *FormSet) rows(values url.Values) map[int]map[string]string {
+	rx := regexp.MustCompile(`^` + regexp.QuoteMeta(s.prefix) + `\[(\d+)\]\.(.+)$`)
+	rows := make(map[int]map[string]string)
+
+	for key := range values {
+		m := rx.FindStringSubmatch(key)
+		if m == nil {
+			continue
+		}
+		index, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		if rows[index] == nil {
+			rows[index] = make(map[string]string)
+		}
+		rows[index][m[2]] = values.Get(key)
+	}
+	return rows
+}
+
+// isBlankRow checks if every value of the row is empty.
+func isBlankRow(row map[string]string) bool {
+	for _, v := range row {
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Forms returns the Form of each row, in order of the row index.
+func (s *FormSet) Forms() []*Form {
+	return s.forms
+}
+
+// Len returns the number of rows.
+func (s *FormSet) Len() int {
+	return len(s.forms)
+}
+
+// Errors returns the errors of the formset itself, like too few rows.
+func (s *FormSet) Errors() []error {
+	return s.errs
+}
+
+// IsValid checks that the formset and every row is valid.
+func (s *FormSet) IsValid() bool {
+	if len(s.errs) > 0 {
+		return false
+	}
+	for _, form := range s.forms {
+		if !form.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrorMap returns the error messages keyed by the submitted field name,
+// like "items[0].name". The non-field errors of a row are keyed by the
+// row, like "items[0]", and the errors of the formset are keyed by "".
+func (s *FormSet) ErrorMap() map[string][]string {
+	errs := make(map[string][]string)
+
+	for i, form := range s.forms {
+		row := s.rowName(i)
+		for name, msgs := range form.ErrorMap() {
+			if name == "" {
+				errs[row] = append(errs[row], msgs...)
+			} else {
+				errs[row+"."+name] = msgs
+			}
+		}
+	}
+	for _, err := range s.errs {
+		errs[""] = append(errs[""], err.Error())
+	}
+
+	return errs
+}
+
+// ErrorsJson returns the formset's errors as ValidationErrors, where the
+// fields are keyed like ErrorMap, and the formset's errors are non-field errors.
+func (s *FormSet) ErrorsJson() ValidationErrors {
+	errs := ValidationErrors{
+		Fields:   make(map[string]string),
+		NonField: []string{},
+	}
+
+	for name, msgs := range s.ErrorMap() {
+		if name == "" {
+			errs.NonField = append(errs.NonField, msgs...)
+		} else {
+			errs.Fields[name] = strings.Join(msgs, ", ")
+		}
+	}
+
+	return errs
+}
+
+// rowName returns the submitted name of the i-th row, like "items[0]".
+func (s *FormSet) rowName(i int) string {
+	return s.prefix + "[" + strconv.Itoa(s.indexes[i]) + "]"
+}
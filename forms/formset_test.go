@@ -0,0 +1,101 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Type: FormSet
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestFormSet(t *testing.T) {
+	cleanItem := func(f *Form, get func(string) string) {
+		f.CleanString("name", get("name"), StrRequired())
+		f.CleanInteger("qty", get("qty"), IntGte(1))
+		f.CleanExtra(get("name") == "Durian", errors.New("is out of stock"))
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		items := NewFormSet("items", 1, 5)
+		items.Clean(url.Values{
+			"items[1].name": {"Pear"},
+			"items[1].qty":  {"1"},
+			"items[0].name": {"Apple"},
+			"items[0].qty":  {"3"},
+			"items[2].name": {""},
+			"items[2].qty":  {""},
+			"other[0].name": {"Kiwi"},
+			"items.name":    {"Fig"},
+		}, cleanItem)
+
+		assert.Equal(t, items.IsValid(), true)
+		assert.Equal(t, items.Len(), 2)
+		assert.Equal(t, items.Forms()[0].CleanedString("name"), "Apple")
+		assert.Equal(t, items.Forms()[0].CleanedInteger("qty"), 3)
+		assert.Equal(t, items.Forms()[1].CleanedString("name"), "Pear")
+		assert.Len(t, items.ErrorMap(), 0)
+	})
+
+	t.Run("invalid rows", func(t *testing.T) {
+		items := NewFormSet("items", 1, 5)
+		items.Clean(url.Values{
+			"items[0].name": {"Apple"},
+			"items[0].qty":  {"3"},
+			"items[3].name": {""},
+			"items[3].qty":  {"0"},
+			"items[7].name": {"Durian"},
+			"items[7].qty":  {"1"},
+		}, cleanItem)
+
+		assert.Equal(t, items.IsValid(), false)
+		assert.Len(t, items.Errors(), 0)
+		assert.Equal(t, items.ErrorMap(), map[string][]string{
+			"items[3].name": {"cannot be blank"},
+			"items[3].qty":  {"must be more than or equal to 1"},
+			"items[7]":      {"is out of stock"},
+		})
+		assert.Equal(t, items.ErrorsJson(), ValidationErrors{
+			Fields: map[string]string{
+				"items[3].name": "cannot be blank",
+				"items[3].qty":  "must be more than or equal to 1",
+				"items[7]":      "is out of stock",
+			},
+			NonField: []string{},
+		})
+	})
+
+	t.Run("row counts", func(t *testing.T) {
+		items := NewFormSet("items", 1, 2)
+		items.Clean(url.Values{}, cleanItem)
+		assert.Equal(t, items.IsValid(), false)
+		assert.Equal(t, items.ErrorMap(), map[string][]string{"": {"must have at least 1 row"}})
+
+		items.Clean(url.Values{
+			"items[0].name": {"Apple"},
+			"items[1].name": {"Pear"},
+			"items[2].name": {"Fig"},
+		}, func(f *Form, get func(string) string) {
+			f.CleanString("name", get("name"))
+		})
+		assert.Equal(t, items.IsValid(), false)
+		assert.Equal(t, items.Len(), 3)
+		assert.Equal(t, items.ErrorsJson().NonField, []string{"must have at most 2 rows"})
+
+		// No limit.
+		items = NewFormSet("items", 0, 0)
+		items.Clean(url.Values{}, cleanItem)
+		assert.Equal(t, items.IsValid(), true)
+	})
+}
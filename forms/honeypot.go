@@ -0,0 +1,65 @@
+package forms
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/tunedmystic/rio/format"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Spam Protection
+//
+//
+// ------------------------------------------------------------------
+
+// errSpam is the error of a submission which looks like spam. It is generic,
+// so that it does not tell a bot which of the checks it failed.
+var errSpam = errors.New("the form could not be submitted, please try again")
+
+// CleanHoneypot checks the value of a honeypot field, which is hidden from
+// users, so that it is only filled in by bots. If it has any value, a generic
+// non-field error is added, and the form is not valid.
+//
+//	<input name="website" class="hidden" tabindex="-1" autocomplete="off">
+//
+//	form.CleanHoneypot("website", r.FormValue("website"))
+//
+// .
+func (f *Form) CleanHoneypot(name, value string) {
+	f.CleanExtra(value != "", errSpam)
+}
+
+// Timestamp returns the current time as a unix timestamp,
+// to render in a hidden field for CleanSubmitTime.
+//
+//	<input type="hidden" name="ts" value="{{ .Timestamp }}">
+//
+// .
+func Timestamp() string {
+	return strconv.FormatInt(format.Now().Unix(), 10)
+}
+
+// CleanSubmitTime checks that the form was submitted at least min after
+// the value, which is a timestamp from Timestamp, as bots submit forms
+// faster than people. If the value is missing, invalid, in the future,
+// or too recent, a generic non-field error is added.
+//
+// The timestamp is not signed, so it only stops simple bots.
+//
+//	form.CleanSubmitTime(r.FormValue("ts"), 3*time.Second)
+//
+// .
+func (f *Form) CleanSubmitTime(value string, min time.Duration) {
+	ts, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		f.CleanExtra(true, errSpam)
+		return
+	}
+
+	elapsed := format.Now().Sub(time.Unix(ts, 0))
+	f.CleanExtra(elapsed < 0 || elapsed < min, errSpam)
+}
@@ -0,0 +1,59 @@
+package forms
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/tunedmystic/rio/assert"
+	"github.com/tunedmystic/rio/format"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Spam Protection
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestSpamProtection(t *testing.T) {
+	t.Run("CleanHoneypot", func(t *testing.T) {
+		form := New()
+		form.CleanHoneypot("website", "")
+		assert.Equal(t, form.IsValid(), true)
+
+		form = New()
+		form.CleanHoneypot("website", "http://spam.example")
+		assert.Equal(t, form.IsValid(), false)
+		assert.Equal(t, form.ErrorMap(), map[string][]string{
+			"": {"the form could not be submitted, please try again"},
+		})
+	})
+
+	t.Run("CleanSubmitTime", func(t *testing.T) {
+		now := time.Date(2024, 8, 15, 12, 0, 0, 0, time.UTC)
+		format.SetClock(func() time.Time { return now })
+		defer format.SetClock(nil)
+
+		assert.Equal(t, Timestamp(), strconv.FormatInt(now.Unix(), 10))
+
+		tests := map[string]bool{
+			strconv.FormatInt(now.Add(-10*time.Second).Unix(), 10): true,
+			strconv.FormatInt(now.Add(-5*time.Second).Unix(), 10):  true,
+			strconv.FormatInt(now.Add(-1*time.Second).Unix(), 10):  false,
+			strconv.FormatInt(now.Add(time.Minute).Unix(), 10):     false,
+			"":    false,
+			"abc": false,
+		}
+		for value, valid := range tests {
+			form := New()
+			form.CleanSubmitTime(value, 5*time.Second)
+			assert.Equal(t, form.IsValid(), valid)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package forms
+
+import (
+	"context"
+	"errors"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Lookup Check Functions
+//
+//
+// ------------------------------------------------------------------
+
+// errNotUnique is the error of a value which is already taken.
+var errNotUnique = errors.New("is already taken")
+
+// Unique returns a CheckFunc which checks that a string is unique with the func,
+// which reports if the value is unique, like a lookup of registered emails.
+//
+// If the func returns an error, the check fails with a LookupError, which is
+// collected as a non-field error. Use HasError to respond with a server error.
+//
+//	form.CleanString("email", r.FormValue("email"), forms.StrEmail(), forms.Unique(func(email string) (bool, error) {
+//		exists, err := users.EmailExists(email)
+//		return !exists, err
+//	}))
+//
+//	if form.HasError(&forms.LookupError{}) {
+//		return err // 500
+//	}
+//
+// .
+func Unique(fn func(value string) (bool, error)) CheckFunc {
+	return func(v Field) error {
+		return uniqueErr(fn(v.String))
+	}
+}
+
+// UniqueCtx returns a CheckFuncCtx which checks that a string is unique
+// with the func, which is given the context. See Unique.
+func UniqueCtx(fn func(ctx context.Context, value string) (bool, error)) CheckFuncCtx {
+	return func(ctx context.Context, v Field) error {
+		return uniqueErr(fn(ctx, v.String))
+	}
+}
+
+// uniqueErr returns the error of the result of a uniqueness lookup.
+func uniqueErr(unique bool, err error) error {
+	if err != nil {
+		return LookupError{Err: err}
+	}
+	if !unique {
+		return errNotUnique
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package forms
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Lookup CheckFuncs
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestLookupCheckFuncs(t *testing.T) {
+	errDB := errors.New("connection refused")
+
+	registered := func(email string) (bool, error) {
+		switch email {
+		case "taken@example.com":
+			return false, nil
+		case "down@example.com":
+			return false, errDB
+		}
+		return true, nil
+	}
+
+	t.Run("Unique", func(t *testing.T) {
+		form := New()
+		form.CleanString("email", "new@example.com", StrEmail(), Unique(registered))
+		assert.Equal(t, form.IsValid(), true)
+
+		form = New()
+		form.CleanString("email", "taken@example.com", StrEmail(), Unique(registered))
+		assert.Equal(t, form.MustField("email").Err().Error(), "is already taken")
+		assert.Equal(t, form.HasError(&LookupError{}), false)
+	})
+
+	t.Run("lookup failure is a non-field error", func(t *testing.T) {
+		form := New()
+		form.Prefix("user").CleanString("email", "down@example.com", Unique(registered))
+
+		assert.Equal(t, form.IsValid(), false)
+		assert.Nil(t, form.MustField("user.email").Err())
+		assert.Equal(t, form.HasError(&LookupError{}), true)
+		assert.Equal(t, form.ErrorMap(), map[string][]string{
+			"": {"user.email could not be checked, please try again"},
+		})
+
+		var lookupErr LookupError
+		assert.ErrorAs(t, form.ExtraErrors()[0], &lookupErr)
+		assert.Equal(t, lookupErr.Name, "user.email")
+		assert.ErrorIs(t, lookupErr, errDB)
+	})
+
+	t.Run("UniqueCtx", func(t *testing.T) {
+		check := UniqueCtx(func(ctx context.Context, email string) (bool, error) {
+			return registered(email)
+		})
+
+		form := New()
+		form.CleanStringCtx(context.Background(), "a", "new@example.com", check)
+		form.CleanStringCtx(context.Background(), "b", "taken@example.com", check)
+		form.CleanStringCtx(context.Background(), "c", "down@example.com", check)
+
+		assert.Nil(t, form.MustField("a").Err())
+		assert.Equal(t, form.MustField("b").Err(), errNotUnique)
+		assert.Nil(t, form.MustField("c").Err())
+		assert.Equal(t, form.HasError(&LookupError{}), true)
+	})
+}
@@ -0,0 +1,176 @@
+package forms
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tunedmystic/rio/decimal"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Money
+//
+//
+// ------------------------------------------------------------------
+
+// Money is an amount of a currency, which is an ISO 4217 code, like "USD".
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+}
+
+// String returns the amount with the currency's minor units, like "10.00 USD".
+func (m Money) String() string {
+	return m.Amount.FormatString(currencies[m.Currency], false) + " " + m.Currency
+}
+
+// currencies are the supported ISO 4217 currency codes, and their number of minor units.
+var currencies = map[string]int{
+	"AED": 2, "ARS": 2, "AUD": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2,
+	"CLP": 0, "CNY": 2, "COP": 2, "CZK": 2, "DKK": 2, "EGP": 2, "EUR": 2,
+	"GBP": 2, "HKD": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "ISK": 0,
+	"JOD": 3, "JPY": 0, "KES": 2, "KRW": 0, "KWD": 3, "MXN": 2, "MYR": 2,
+	"NGN": 2, "NOK": 2, "NZD": 2, "OMR": 3, "PHP": 2, "PKR": 2, "PLN": 2,
+	"QAR": 2, "RON": 2, "SAR": 2, "SEK": 2, "SGD": 2, "THB": 2, "TRY": 2,
+	"TWD": 2, "UAH": 2, "USD": 2, "VND": 0, "ZAR": 2,
+}
+
+// CleanMoney cleans the given amount as money of the currency, which is
+// an ISO 4217 code, like "USD". The amount can be grouped by thousands with
+// commas, and cannot have more decimal places than the currency's minor units.
+//
+//	form.CleanMoney("price", r.FormValue("price"), r.FormValue("currency"), forms.MoneyGte("1.00 USD"))
+//
+// .
+func (f *Form) CleanMoney(name, amount, currency string, funcs ...CheckFunc) {
+	f.cleanField(name, "money", parseMoney(amount, currency), funcs...)
+}
+
+// CleanedMoney retrieves the named field as money.
+func (f *Form) CleanedMoney(name string) Money {
+	return f.MustField(name).Money
+}
+
+// parseMoney parses the amount and currency into a money Field.
+func parseMoney(val, currency string) Field {
+	if val == "" {
+		return Field{val: val, isBlank: true}
+	}
+
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	units, ok := currencies[currency]
+	if !ok {
+		return Field{val: val, err: errParseCurr}
+	}
+
+	amount, err := decimal.Parse(strings.ReplaceAll(strings.TrimSpace(val), ",", ""))
+	if err != nil {
+		return Field{val: val, err: errParseBigRat}
+	}
+	if !amount.Round(units, decimal.RoundDown).Equal(amount) {
+		return Field{val: val, err: ParseError{fmt.Sprintf("must have at most %d decimal places", units)}}
+	}
+
+	return Field{val: val, Money: Money{Amount: amount, Currency: currency}}
+}
+
+// parseMoneyString parses money with the currency, like "10.00 USD".
+func parseMoneyString(val string) Field {
+	amount, currency, ok := strings.Cut(strings.TrimSpace(val), " ")
+	if !ok {
+		return Field{val: val, err: errParseCurr}
+	}
+	return parseMoney(amount, currency)
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Money Check Functions
+//
+//
+// ------------------------------------------------------------------
+
+// Checks that money is not blank.
+func MoneyRequired() CheckFunc {
+	return func(v Field) error {
+		if v.IsBlank() {
+			return errBlankValue
+		}
+		return nil
+	}
+}
+
+// Checks that money is of one of the currencies.
+func MoneyIn(codes ...string) CheckFunc {
+	return func(v Field) error {
+		for _, c := range codes {
+			if strings.EqualFold(c, v.Money.Currency) {
+				return nil
+			}
+		}
+		return errInvalidChoice
+	}
+}
+
+// Checks that money is less than n, like "10.00 USD".
+func MoneyLt(n string) CheckFunc {
+	nn := parseMoneyString(n)
+	return func(v Field) error { return moneyCheck(v, nn, errLessThan, func(r int) bool { return r < 0 }) }
+}
+
+// Checks that money is less than or equal to n, like "10.00 USD".
+func MoneyLte(n string) CheckFunc {
+	nn := parseMoneyString(n)
+	return func(v Field) error { return moneyCheck(v, nn, errLessThanOrEqual, func(r int) bool { return r <= 0 }) }
+}
+
+// Checks that money is more than n, like "10.00 USD".
+func MoneyGt(n string) CheckFunc {
+	nn := parseMoneyString(n)
+	return func(v Field) error { return moneyCheck(v, nn, errGreaterThan, func(r int) bool { return r > 0 }) }
+}
+
+// Checks that money is more than or equal to n, like "10.00 USD".
+func MoneyGte(n string) CheckFunc {
+	nn := parseMoneyString(n)
+	return func(v Field) error {
+		return moneyCheck(v, nn, errGreaterThanOrEqual, func(r int) bool { return r >= 0 })
+	}
+}
+
+// Checks that money is between n and m, like "10.00 USD" and "99.99 USD".
+func MoneyBtw(n, m string) CheckFunc {
+	nn, mm := parseMoneyString(n), parseMoneyString(m)
+
+	return func(v Field) error {
+		if nn.Err() != nil || mm.Err() != nil || nn.Money.Currency != mm.Money.Currency ||
+			nn.Money.Amount.Cmp(mm.Money.Amount) >= 0 {
+			return errInvalidConfig
+		}
+		if v.Money.Currency != nn.Money.Currency {
+			return fmt.Errorf("must be in %s", nn.Money.Currency)
+		}
+		if v.Money.Amount.Cmp(nn.Money.Amount) < 0 || v.Money.Amount.Cmp(mm.Money.Amount) > 0 {
+			return fmt.Errorf(errBetween, nn.Money, mm.Money)
+		}
+		return nil
+	}
+}
+
+// moneyCheck compares money to nn. The money must be of the
+// same currency as nn, and valid if ok(v.Cmp(nn)) is true.
+func moneyCheck(v, nn Field, errMsg string, ok func(int) bool) error {
+	if nn.Err() != nil {
+		return errInvalidConfig
+	}
+	if v.Money.Currency != nn.Money.Currency {
+		return fmt.Errorf("must be in %s", nn.Money.Currency)
+	}
+	if !ok(v.Money.Amount.Cmp(nn.Money.Amount)) {
+		return fmt.Errorf(errMsg, nn.Money)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package forms
+
+import (
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Type: Money
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestCleanMoney(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tests := []struct {
+			amount   string
+			currency string
+			want     string
+		}{
+			{"10", "USD", "10.00 USD"},
+			{"1,234.5", "usd", "1234.50 USD"},
+			{" 19.99 ", "EUR", "19.99 EUR"},
+			{"-5.25", "GBP", "-5.25 GBP"},
+			{"500", "JPY", "500 JPY"},
+			{"1.125", "KWD", "1.125 KWD"},
+		}
+
+		for _, test := range tests {
+			form := New()
+			form.CleanMoney("price", test.amount, test.currency)
+			assert.Nil(t, form.MustField("price").Err())
+			assert.Equal(t, form.CleanedMoney("price").String(), test.want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			amount   string
+			currency string
+			want     string
+		}{
+			{"10", "", "must be a valid currency"},
+			{"10", "XYZ", "must be a valid currency"},
+			{"ten", "USD", "must be a valid decimal"},
+			{"10.001", "USD", "must have at most 2 decimal places"},
+			{"500.5", "JPY", "must have at most 0 decimal places"},
+		}
+
+		for _, test := range tests {
+			form := New()
+			form.CleanMoney("price", test.amount, test.currency)
+			assert.Equal(t, form.MustField("price").Err().Error(), test.want)
+		}
+	})
+
+	t.Run("blank", func(t *testing.T) {
+		form := New()
+		form.CleanMoney("price", "", "USD", MoneyRequired())
+		assert.Equal(t, form.MustField("price").Err(), errBlankValue)
+	})
+}
+
+func TestMoneyCheckFuncs(t *testing.T) {
+	usd := parseMoney("10.00", "USD")
+	eur := parseMoney("10.00", "EUR")
+
+	t.Run("MoneyIn", func(t *testing.T) {
+		assert.Equal(t, MoneyIn("usd", "EUR")(usd), nil)
+		assert.Equal(t, MoneyIn("GBP")(usd), errInvalidChoice)
+	})
+
+	t.Run("MoneyLt", func(t *testing.T) {
+		assert.Equal(t, MoneyLt("10.01 USD")(usd), nil)
+		assert.Equal(t, MoneyLt("10 USD")(usd).Error(), "must be less than 10.00 USD")
+	})
+
+	t.Run("MoneyLte", func(t *testing.T) {
+		assert.Equal(t, MoneyLte("10 USD")(usd), nil)
+		assert.Equal(t, MoneyLte("9.99 USD")(usd).Error(), "must be less than or equal to 9.99 USD")
+	})
+
+	t.Run("MoneyGt", func(t *testing.T) {
+		assert.Equal(t, MoneyGt("9.99 USD")(usd), nil)
+		assert.Equal(t, MoneyGt("10 USD")(usd).Error(), "must be more than 10.00 USD")
+	})
+
+	t.Run("MoneyGte", func(t *testing.T) {
+		assert.Equal(t, MoneyGte("10.00 USD")(usd), nil)
+		assert.Equal(t, MoneyGte("10.01 USD")(usd).Error(), "must be more than or equal to 10.01 USD")
+	})
+
+	t.Run("MoneyBtw", func(t *testing.T) {
+		assert.Equal(t, MoneyBtw("1 USD", "10 USD")(usd), nil)
+		assert.Equal(t, MoneyBtw("1 USD", "5 USD")(usd).Error(), "must be between 1.00 USD and 5.00 USD")
+		assert.Equal(t, MoneyBtw("1 USD", "10 EUR")(usd), errInvalidConfig)
+		assert.Equal(t, MoneyBtw("10 USD", "1 USD")(usd), errInvalidConfig)
+	})
+
+	t.Run("currency mismatch", func(t *testing.T) {
+		assert.Equal(t, MoneyGte("1.00 USD")(eur).Error(), "must be in USD")
+		assert.Equal(t, MoneyBtw("1 USD", "10 USD")(eur).Error(), "must be in USD")
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		assert.Equal(t, MoneyGte("10.00")(usd), errInvalidConfig)
+		assert.Equal(t, MoneyGte("10.00 XYZ")(usd), errInvalidConfig)
+		assert.Equal(t, MoneyGte("ten USD")(usd), errInvalidConfig)
+	})
+}
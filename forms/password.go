@@ -0,0 +1,156 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Password Check Functions
+//
+//
+// ------------------------------------------------------------------
+
+// CharClass is a class of characters which a password can be required to contain.
+type CharClass int
+
+const (
+	Lowercase CharClass = iota
+	Uppercase
+	Digit
+	Symbol
+)
+
+// String returns the description of the character class, like "a lowercase letter".
+func (c CharClass) String() string {
+	switch c {
+	case Lowercase:
+		return "a lowercase letter"
+	case Uppercase:
+		return "an uppercase letter"
+	case Digit:
+		return "a digit"
+	case Symbol:
+		return "a symbol"
+	}
+	return "an unknown character class"
+}
+
+// contains checks if the string contains a character of the class.
+func (c CharClass) contains(s string) bool {
+	return strings.ContainsFunc(s, func(r rune) bool {
+		return charClassOf(r) == c
+	})
+}
+
+// charClassOf returns the class of the character. Characters which
+// are not letters or digits, like spaces and punctuation, are symbols.
+func charClassOf(r rune) CharClass {
+	switch {
+	case unicode.IsLower(r):
+		return Lowercase
+	case unicode.IsUpper(r):
+		return Uppercase
+	case unicode.IsDigit(r):
+		return Digit
+	}
+	return Symbol
+}
+
+// Checks that a password has at least minLen characters,
+// and contains a character of each of the required classes.
+//
+//	forms.StrPassword(12, forms.Lowercase, forms.Uppercase, forms.Digit)
+//
+// .
+func StrPassword(minLen int, requireClasses ...CharClass) CheckFunc {
+	errLen := fmt.Errorf("must be at least %d characters", minLen)
+
+	return func(v Field) error {
+		if utf8.RuneCountInString(v.Value()) < minLen {
+			return errLen
+		}
+		for _, class := range requireClasses {
+			if !class.contains(v.Value()) {
+				return fmt.Errorf("must contain %s", class)
+			}
+		}
+		return nil
+	}
+}
+
+// errWeakPassword is the error of a password with too little entropy.
+var errWeakPassword = errors.New("is too easy to guess")
+
+// Checks that a password has an estimated entropy of at least the bits.
+// As a guide, 28 bits is weak, 36 bits is fair and 60 bits is strong.
+//
+// The estimate is based on the length and the character classes used,
+// where repeated and sequential characters, like "aaa" or "abc", count for
+// less, and common passwords have no entropy.
+func StrEntropy(bits float64) CheckFunc {
+	return func(v Field) error {
+		if passwordEntropy(v.Value()) < bits {
+			return errWeakPassword
+		}
+		return nil
+	}
+}
+
+// charClassSizes are the number of characters in each class, for the entropy estimate.
+var charClassSizes = map[CharClass]float64{
+	Lowercase: 26,
+	Uppercase: 26,
+	Digit:     10,
+	Symbol:    33,
+}
+
+// commonPasswords are passwords which are guessed first.
+var commonPasswords = map[string]bool{
+	"password": true, "passw0rd": true, "password1": true, "123456": true,
+	"12345678": true, "123456789": true, "1234567890": true, "qwerty": true,
+	"qwertyuiop": true, "letmein": true, "welcome": true, "iloveyou": true,
+	"admin": true, "abc123": true, "monkey": true, "dragon": true,
+	"football": true, "baseball": true, "sunshine": true, "trustno1": true,
+}
+
+// passwordEntropy estimates the entropy of the password, in bits.
+func passwordEntropy(s string) float64 {
+	if s == "" || commonPasswords[strings.ToLower(s)] {
+		return 0
+	}
+
+	classes := map[CharClass]bool{}
+	length := 0.0
+	prev, delta := rune(-1), rune(0)
+
+	for _, r := range s {
+		classes[charClassOf(r)] = true
+
+		// Repeated and sequential characters count for less.
+		switch d := r - prev; {
+		case prev >= 0 && d == 0:
+			length += 0.25
+		case prev >= 0 && (d == 1 || d == -1) && d == delta:
+			length += 0.25
+		default:
+			length++
+		}
+		if prev >= 0 {
+			delta = r - prev
+		}
+		prev = r
+	}
+
+	pool := 0.0
+	for class := range classes {
+		pool += charClassSizes[class]
+	}
+	return length * math.Log2(pool)
+}
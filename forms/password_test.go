@@ -0,0 +1,83 @@
+package forms
+
+import (
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Password CheckFuncs
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestPasswordCheckFuncs(t *testing.T) {
+	t.Run("StrPassword", func(t *testing.T) {
+		check := StrPassword(8, Lowercase, Uppercase, Digit, Symbol)
+
+		// ok
+		err1 := check(parseString("Tr0ub4dor&3"))
+		assert.Equal(t, err1, nil)
+
+		// ok, no required classes
+		err2 := StrPassword(4)(parseString("abcd"))
+		assert.Equal(t, err2, nil)
+
+		// error, too short
+		err3 := check(parseString("Ab1!"))
+		assert.Equal(t, err3.Error(), "must be at least 8 characters")
+
+		// error, the first missing class
+		err4 := check(parseString("troub4dor&3"))
+		assert.Equal(t, err4.Error(), "must contain an uppercase letter")
+
+		err5 := check(parseString("Troubador&x"))
+		assert.Equal(t, err5.Error(), "must contain a digit")
+
+		err6 := check(parseString("Troub4dor3"))
+		assert.Equal(t, err6.Error(), "must contain a symbol")
+	})
+
+	t.Run("StrEntropy", func(t *testing.T) {
+		check := StrEntropy(36)
+
+		// ok
+		err1 := check(parseString("Tr0ub4dor&3"))
+		assert.Equal(t, err1, nil)
+
+		err2 := check(parseString("correct horse battery staple"))
+		assert.Equal(t, err2, nil)
+
+		// error, repeated characters
+		err3 := check(parseString("aaaaaaaaaaaa"))
+		assert.Equal(t, err3.Error(), "is too easy to guess")
+
+		// error, sequential characters
+		err4 := check(parseString("abcdefghijkl"))
+		assert.Equal(t, err4.Error(), "is too easy to guess")
+
+		// error, common password
+		err5 := check(parseString("Password1"))
+		assert.Equal(t, err5.Error(), "is too easy to guess")
+	})
+
+	t.Run("passwordEntropy", func(t *testing.T) {
+		assert.Equal(t, passwordEntropy(""), 0.0)
+		assert.Equal(t, passwordEntropy("qwerty"), 0.0)
+
+		// More character classes give more entropy.
+		assert.Equal(t, passwordEntropy("hunter") < passwordEntropy("Hunter"), true)
+		assert.Equal(t, passwordEntropy("Hunter") < passwordEntropy("Hunt3r"), true)
+
+		// Repeats and sequences give less entropy.
+		assert.Equal(t, passwordEntropy("aaaa") < passwordEntropy("amqz"), true)
+		assert.Equal(t, passwordEntropy("4321") < passwordEntropy("4913"), true)
+	})
+}
@@ -0,0 +1,153 @@
+package forms
+
+import "strings"
+
+// ------------------------------------------------------------------
+//
+//
+// Phone Numbers
+//
+//
+// ------------------------------------------------------------------
+
+// CleanPhone cleans the given value as a phone number, which is normalized
+// to E.164, like "+14155550123". A number in international format, starting
+// with "+" or "00", can be from any region. Otherwise, it is parsed as a
+// national number of the region, which is an ISO 3166 country code, like "US".
+// Spaces, dashes, dots and parentheses are ignored.
+//
+//	form.CleanPhone("phone", "(415) 555-0123", "US")
+//	form.CleanedPhone("phone") // "+14155550123"
+//
+// .
+func (f *Form) CleanPhone(name, value, region string, funcs ...CheckFunc) {
+	f.cleanField(name, "phone", parsePhone(value, region), funcs...)
+}
+
+// CleanedPhone retrieves the named field as an E.164 phone number.
+func (f *Form) CleanedPhone(name string) string {
+	return f.MustField(name).String
+}
+
+// parsePhone parses the value into an E.164 phone number Field.
+func parsePhone(val, region string) Field {
+	if val == "" {
+		return Field{val: val, isBlank: true}
+	}
+
+	phone, ok := normalizePhone(val, region)
+	if !ok {
+		return Field{val: val, err: errParsePhone}
+	}
+
+	return Field{val: val, String: phone}
+}
+
+// Checks that a string is a phone number, in international format,
+// or a national number of the region. Use CleanPhone to also normalize the number.
+func StrPhone(region string) CheckFunc {
+	return func(v Field) error {
+		if _, ok := normalizePhone(v.String, region); !ok {
+			return errParsePhone
+		}
+		return nil
+	}
+}
+
+// phoneRegion holds the numbering plan of a region.
+type phoneRegion struct {
+	code     string // The country calling code.
+	trunk    string // The prefix of national numbers, which is dropped in E.164.
+	min, max int    // The length of national numbers, without the trunk prefix.
+}
+
+// phoneRegions are the numbering plans of the supported regions.
+var phoneRegions = map[string]phoneRegion{
+	"AU": {"61", "0", 9, 9},
+	"BR": {"55", "0", 10, 11},
+	"CA": {"1", "1", 10, 10},
+	"CN": {"86", "0", 10, 11},
+	"DE": {"49", "0", 6, 13},
+	"ES": {"34", "", 9, 9},
+	"FR": {"33", "0", 9, 9},
+	"GB": {"44", "0", 9, 10},
+	"IE": {"353", "0", 7, 9},
+	"IN": {"91", "0", 10, 10},
+	"IT": {"39", "", 6, 11},
+	"JP": {"81", "0", 9, 10},
+	"MX": {"52", "", 10, 10},
+	"NL": {"31", "0", 9, 9},
+	"NZ": {"64", "0", 8, 10},
+	"SG": {"65", "", 8, 8},
+	"US": {"1", "1", 10, 10},
+	"ZA": {"27", "0", 9, 9},
+}
+
+// normalizePhone returns the E.164 form of the phone number.
+func normalizePhone(val, region string) (string, bool) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')', '/':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(val))
+
+	// International format.
+	if rest, ok := strings.CutPrefix(digits, "+"); ok {
+		return normalizeIntlPhone(rest)
+	}
+	if rest, ok := strings.CutPrefix(digits, "00"); ok {
+		return normalizeIntlPhone(rest)
+	}
+
+	// National format.
+	plan, ok := phoneRegions[strings.ToUpper(region)]
+	if !ok || !isDigits(digits) {
+		return "", false
+	}
+	if plan.trunk != "" && len(digits) > plan.min {
+		digits = strings.TrimPrefix(digits, plan.trunk)
+	}
+	if !plan.valid(digits) {
+		return "", false
+	}
+	return "+" + plan.code + digits, true
+}
+
+// normalizeIntlPhone returns the E.164 form of the phone number, which
+// starts with the country calling code. Numbers of the supported regions
+// are checked against their numbering plan, and other numbers are only
+// checked to have between 8 and 15 digits.
+func normalizeIntlPhone(digits string) (string, bool) {
+	if !isDigits(digits) || len(digits) < 8 || len(digits) > 15 {
+		return "", false
+	}
+
+	for _, plan := range phoneRegions {
+		if national, ok := strings.CutPrefix(digits, plan.code); ok {
+			if !plan.valid(national) {
+				return "", false
+			}
+			break
+		}
+	}
+	return "+" + digits, true
+}
+
+// valid checks if the national number fits the numbering plan.
+// In the North American plan, the area code cannot start with 0 or 1.
+func (p phoneRegion) valid(national string) bool {
+	if len(national) < p.min || len(national) > p.max {
+		return false
+	}
+	if p.code == "1" && (national[0] == '0' || national[0] == '1') {
+		return false
+	}
+	return true
+}
+
+// isDigits checks if the string is not empty, and only has ascii digits.
+func isDigits(s string) bool {
+	return s != "" && !strings.ContainsFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+}
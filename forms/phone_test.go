@@ -0,0 +1,94 @@
+package forms
+
+import (
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Phone Numbers
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestCleanPhone(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tests := []struct {
+			value  string
+			region string
+			want   string
+		}{
+			{"(415) 555-0123", "US", "+14155550123"},
+			{"1-415-555-0123", "us", "+14155550123"},
+			{"415.555.0123", "CA", "+14155550123"},
+			{"020 7946 0958", "GB", "+442079460958"},
+			{"07700 900123", "GB", "+447700900123"},
+			{"01 23 45 67 89", "FR", "+33123456789"},
+			{"06 12 34 56 78", "FR", "+33612345678"},
+			{"030 1234567", "DE", "+49301234567"},
+			{"06 1234 5678", "IT", "+390612345678"},
+			{"+44 20 7946 0958", "US", "+442079460958"},
+			{"0044 20 7946 0958", "", "+442079460958"},
+			{"+81 3-1234-5678", "", "+81312345678"},
+			{"+372 5123 4567", "", "+37251234567"},
+		}
+
+		for _, test := range tests {
+			form := New()
+			form.CleanPhone("phone", test.value, test.region)
+			assert.Nil(t, form.MustField("phone").Err())
+			assert.Equal(t, form.CleanedPhone("phone"), test.want)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			value  string
+			region string
+		}{
+			{"555-0123", "US"},
+			{"(015) 555-0123", "US"},
+			{"415-555-01234", "US"},
+			{"415-555-CALL", "US"},
+			{"020 7946 0958", ""},
+			{"020 7946 0958", "XX"},
+			{"+44 20 79", ""},
+			{"+1 415 555 01234", ""},
+			{"+1234567890123456", ""},
+			{"++14155550123", ""},
+		}
+
+		for _, test := range tests {
+			form := New()
+			form.CleanPhone("phone", test.value, test.region)
+			assert.Equal(t, form.MustField("phone").Err(), error(errParsePhone))
+		}
+	})
+
+	t.Run("blank", func(t *testing.T) {
+		form := New()
+		form.CleanPhone("phone", "", "US", StrRequired())
+		assert.Equal(t, form.MustField("phone").Err(), errBlankValue)
+	})
+
+	t.Run("StrPhone", func(t *testing.T) {
+		// ok
+		err1 := StrPhone("US")(parseString("(415) 555-0123"))
+		assert.Equal(t, err1, nil)
+
+		// ok, international
+		err2 := StrPhone("US")(parseString("+33 6 12 34 56 78"))
+		assert.Equal(t, err2, nil)
+
+		// error
+		err3 := StrPhone("GB")(parseString("12345"))
+		assert.Equal(t, err3.Error(), "must be a valid phone number")
+	})
+}
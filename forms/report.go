@@ -0,0 +1,151 @@
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: FieldReport
+//
+//
+// ------------------------------------------------------------------
+
+// FieldReport is a summary of how a field was cleaned, for logging
+// failed submissions and for debugging why a form rejected input.
+type FieldReport struct {
+	Name     string   `json:"name"`
+	Value    string   `json:"value"`
+	Type     string   `json:"type"`
+	Blank    bool     `json:"blank"`
+	Checks   []string `json:"checks"`
+	Warnings []string `json:"warnings,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// redacted is the value reported for a field whose value is not shown.
+const redacted = "[REDACTED]"
+
+// ReportOpt is a function to configure a report.
+type ReportOpt func(*reportConfig)
+
+// reportConfig holds the configuration of a report.
+type reportConfig struct {
+	values []string
+}
+
+// ReportValues shows the values of the named fields in the report.
+// Only name the fields which are safe to log, as a value is shown even
+// if it is checked as a password or card number.
+func ReportValues(names ...string) ReportOpt {
+	return func(c *reportConfig) {
+		c.values = append(c.values, names...)
+	}
+}
+
+// Report returns a FieldReport of each field, sorted by name. The checks
+// of a field are the checks which were run, up to the check which failed.
+//
+// Values are redacted by default, as a form can hold passwords, card numbers,
+// or other secrets. The values of the fields named with ReportValues are shown.
+//
+//	if !form.IsValid() {
+//		report := form.Report(forms.ReportValues("email", "age"))
+//		slog.Info("form rejected", "path", r.URL.Path, "report", report)
+//	}
+//
+// .
+func (f *Form) Report(opts ...ReportOpt) []FieldReport {
+	var cfg reportConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	f = f.root()
+	names := f.Names()
+	slices.Sort(names)
+	reports := make([]FieldReport, 0, len(names))
+
+	for _, name := range names {
+		field := f.fields[name]
+
+		report := FieldReport{
+			Name:   name,
+			Value:  redacted,
+			Type:   field.kind,
+			Blank:  field.isBlank,
+			Checks: field.checks,
+		}
+		if report.Type == "" {
+			report.Type = fmt.Sprintf("%T", field.typed)
+		}
+		if report.Checks == nil {
+			report.Checks = []string{}
+		}
+		if field.isBlank || slices.Contains(cfg.values, name) {
+			report.Value = field.val
+		}
+		for _, w := range field.warnings {
+			report.Warnings = append(report.Warnings, w.Error())
+		}
+		if err := field.Err(); err != nil {
+			report.Error = err.Error()
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// checkNames returns the name of each check func.
+func checkNames[F any](checks []F) []string {
+	names := make([]string, len(checks))
+	for i := range checks {
+		names[i] = checkName(checks[i])
+	}
+	return names
+}
+
+// checkName returns the name of the func which made the check, without
+// the package path, like "StrGte" or "Choices.CheckFunc".
+func checkName(fn any) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return "unknown"
+	}
+	rf := runtime.FuncForPC(v.Pointer())
+	if rf == nil {
+		return "unknown"
+	}
+
+	// Trim the package path, like "github.com/tunedmystic/rio/forms.".
+	name := rf.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	// Trim the generic types, like "Gte[...]".
+	name = strings.ReplaceAll(name, "[...]", "")
+	name = strings.TrimSuffix(name, "-fm")
+
+	// Trim the closures, like "StrGte.func1" or "Unique.func1.2".
+	parts := strings.Split(name, ".")
+	for len(parts) > 1 && isClosure(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// isClosure checks if the part of a func name is an anonymous func, like "func1" or "2".
+func isClosure(part string) bool {
+	part = strings.TrimPrefix(part, "func")
+	return part != "" && isDigits(part)
+}
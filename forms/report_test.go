@@ -0,0 +1,91 @@
+package forms
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Type: FieldReport
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestReport(t *testing.T) {
+	t.Run("fields", func(t *testing.T) {
+		form := New()
+		form.CleanString("name", "alice", StrRequired(), StrLte(20))
+		form.CleanInteger("age", "12", IntGte(18), IntLte(99))
+		form.CleanInteger("count", "", IntLte(10))
+		form.CleanInteger("bad", "one", IntGte(1))
+
+		reports := form.Report(ReportValues("age", "bad", "name"))
+		assert.Equal(t, reports, []FieldReport{
+			{Name: "age", Value: "12", Type: "integer", Checks: []string{"IntGte"}, Error: "must be more than or equal to 18"},
+			{Name: "bad", Value: "one", Type: "integer", Checks: []string{}, Error: "must be a valid integer"},
+			{Name: "count", Value: "", Type: "integer", Blank: true, Checks: []string{"IntLte"}},
+			{Name: "name", Value: "alice", Type: "string", Checks: []string{"StrRequired", "StrLte"}},
+		})
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		form := New()
+		form.CleanInteger("qty", "50", Warn(IntLte(10)))
+
+		reports := form.Report()
+		assert.Equal(t, reports[0].Checks, []string{"Warn"})
+		assert.Equal(t, reports[0].Warnings, []string{"must be less than or equal to 10"})
+		assert.Equal(t, reports[0].Error, "")
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		form := New()
+		form.CleanString("pw", "hunter2hunter2", Optional(StrPassword(8)))
+		form.CleanString("card", "4111111111111111", Warn(StrCreditCard()))
+		form.CleanString("email", "alice@example.com")
+		form.CleanString("nickname", "")
+
+		reports := form.Report(ReportValues("email"))
+		assert.Equal(t, reports[0].Value, "[REDACTED]")
+		assert.Equal(t, reports[1].Value, "alice@example.com")
+		assert.Equal(t, reports[2].Value, "")
+		assert.Equal(t, reports[3].Value, "[REDACTED]")
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		form := New()
+		Clean(form, "age", "17", strconv.Atoi, Required[int](), Gte(18))
+
+		reports := form.Report()
+		assert.Equal(t, reports[0].Type, "int")
+		assert.Equal(t, reports[0].Checks, []string{"Required", "Gte"})
+		assert.Equal(t, reports[0].Error, "must be more than or equal to 18")
+	})
+
+	t.Run("context", func(t *testing.T) {
+		available := func(ctx context.Context, v Field) error { return nil }
+
+		form := New()
+		form.CleanStringCtx(context.Background(), "username", "alice", CheckCtx(StrRequired()), available)
+
+		reports := form.Report()
+		assert.Equal(t, reports[0].Checks, []string{"CheckCtx", "TestReport"})
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		form := New()
+		form.Prefix("address").CleanString("city", "Paris")
+
+		reports := form.Report()
+		assert.Equal(t, reports[0].Name, "address.city")
+	})
+}
@@ -0,0 +1,262 @@
+package forms
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: TypedField
+//
+//
+// ------------------------------------------------------------------
+
+// TypedField is a parsed value of type T.
+//
+// Unlike Field, which carries a member for every supported type,
+// a TypedField only carries its own type, so that a check of the
+// wrong type is a compile error.
+type TypedField[T any] struct {
+	val     string
+	isBlank bool
+	value   T
+}
+
+// Value returns the field's original value.
+func (f TypedField[T]) Value() string {
+	return f.val
+}
+
+// IsBlank returns true if the field's original value is empty.
+func (f TypedField[T]) IsBlank() bool {
+	return f.isBlank
+}
+
+// Get returns the field's parsed value.
+func (f TypedField[T]) Get() T {
+	return f.value
+}
+
+// Check is a function which validates a TypedField.
+type Check[T any] func(TypedField[T]) error
+
+// errParseValue is the error of a failed parse func which does not return a ParseError.
+var errParseValue = ParseError{"must be a valid value"}
+
+// Clean cleans the given value with the parse func, and validates it with the checks.
+// A blank value is not parsed, and has the zero value of T.
+//
+//	forms.Clean(form, "age", r.FormValue("age"), strconv.Atoi, forms.Required[int](), forms.Gte(18))
+//	forms.Clean(form, "price", r.FormValue("price"), decimal.Parse, forms.Required[decimal.Decimal]())
+//
+// .
+func Clean[T any](f *Form, name, value string, parse func(string) (T, error), checks ...Check[T]) {
+	field := TypedField[T]{val: value, isBlank: value == ""}
+
+	if !field.isBlank {
+		v, err := parse(value)
+		if err != nil {
+			var parseErr ParseError
+			if !errors.As(err, &parseErr) {
+				parseErr = errParseValue
+			}
+			f.addField(name, Field{val: value, err: parseErr})
+			return
+		}
+		field.value = v
+	}
+
+	var err error
+	names := checkNames(checks)
+	for i := range checks {
+		if err = checks[i](field); err != nil {
+			names = names[:i+1]
+			break
+		}
+	}
+
+	f.addField(name, Field{val: value, err: err, isBlank: field.isBlank, typed: field.value, checks: names})
+}
+
+// Cleaned retrieves the named field's value, which was cleaned with Clean,
+// or with a Clean method of the Form, like CleanInteger or CleanDecimal.
+// It panics if the field does not exist, or is not of type T.
+//
+//	form.CleanInteger("age", r.FormValue("age"))
+//	age := forms.Cleaned[int](form, "age")
+//
+// .
+func Cleaned[T any](f *Form, name string) T {
+	field := f.MustField(name)
+
+	value := field.typed
+	if get, ok := kindValues[field.kind]; ok {
+		value = get(field)
+	}
+	if value == nil {
+		var zero T
+		return zero
+	}
+
+	v, ok := value.(T)
+	if !ok {
+		panic(fmt.Sprintf("field %s is a %T, not a %T", name, value, v))
+	}
+	return v
+}
+
+// kindValues return the member of a Field which holds the value,
+// for each kind of field which is cleaned by a Clean method of the Form.
+var kindValues = map[string]func(Field) any{
+	"string":       func(f Field) any { return f.String },
+	"uuid":         func(f Field) any { return f.String },
+	"phone":        func(f Field) any { return f.String },
+	"integer":      func(f Field) any { return f.Integer },
+	"integer list": func(f Field) any { return f.Integers },
+	"int64":        func(f Field) any { return f.Int64 },
+	"bytes":        func(f Field) any { return f.Int64 },
+	"uint":         func(f Field) any { return f.Uint },
+	"float":        func(f Field) any { return f.Float },
+	"bool":         func(f Field) any { return f.Bool },
+	"checkbox":     func(f Field) any { return f.Bool },
+	"date":         func(f Field) any { return f.Date },
+	"datetime":     func(f Field) any { return f.Date },
+	"time":         func(f Field) any { return f.Date },
+	"decimal":      func(f Field) any { return f.Decimal },
+	"duration":     func(f Field) any { return f.Duration },
+	"money":        func(f Field) any { return f.Money },
+	"file":         func(f Field) any { return f.File },
+}
+
+// CleanEnum cleans the given value as an enum of a string type,
+// which must be one of the choices. A blank value is only
+// validated by the checks, so it is allowed unless Required.
+//
+//	type Status string
+//
+//	const (
+//		Draft     Status = "draft"
+//		Published Status = "published"
+//	)
+//
+//	forms.CleanEnum(form, "status", r.FormValue("status"), []Status{Draft, Published}, forms.Required[Status]())
+//	status := forms.Cleaned[Status](form, "status")
+//
+// .
+func CleanEnum[T ~string](f *Form, name, value string, choices []T, checks ...Check[T]) {
+	parse := func(s string) (T, error) { return T(strings.TrimSpace(s)), nil }
+	in := In(choices...)
+	enum := func(v TypedField[T]) error {
+		if v.IsBlank() {
+			return nil
+		}
+		return in(v)
+	}
+
+	Clean(f, name, value, parse, slices.Concat(checks, []Check[T]{enum})...)
+}
+
+// typedField adapts the Field into a TypedField, for a typed check to validate
+// the member of the Field returned by get. Each check func returns its own
+// closure, rather than a shared adapter, so that Report can name the check.
+func typedField[T any](v Field, get func(Field) T) TypedField[T] {
+	return TypedField[T]{val: v.val, isBlank: v.isBlank, value: get(v)}
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Typed Check Functions
+//
+//
+// ------------------------------------------------------------------
+
+// Checks that a value is not blank.
+func Required[T any]() Check[T] {
+	return func(v TypedField[T]) error {
+		if v.IsBlank() {
+			return errBlankValue
+		}
+		return nil
+	}
+}
+
+// Checks that a value is less than n.
+func Lt[T cmp.Ordered](n T) Check[T] {
+	return func(v TypedField[T]) error {
+		if v.Get() >= n {
+			return fmt.Errorf(errLessThan, n)
+		}
+		return nil
+	}
+}
+
+// Checks that a value is less than or equal to n.
+func Lte[T cmp.Ordered](n T) Check[T] {
+	return func(v TypedField[T]) error {
+		if v.Get() > n {
+			return fmt.Errorf(errLessThanOrEqual, n)
+		}
+		return nil
+	}
+}
+
+// Checks that a value is more than n.
+func Gt[T cmp.Ordered](n T) Check[T] {
+	return func(v TypedField[T]) error {
+		if v.Get() <= n {
+			return fmt.Errorf(errGreaterThan, n)
+		}
+		return nil
+	}
+}
+
+// Checks that a value is more than or equal to n.
+func Gte[T cmp.Ordered](n T) Check[T] {
+	return func(v TypedField[T]) error {
+		if v.Get() < n {
+			return fmt.Errorf(errGreaterThanOrEqual, n)
+		}
+		return nil
+	}
+}
+
+// Checks that a value is between n and m.
+func Btw[T cmp.Ordered](n, m T) Check[T] {
+	return func(v TypedField[T]) error {
+		if n >= m {
+			return errInvalidConfig
+		}
+		if (v.Get() < n) || (v.Get() > m) {
+			return fmt.Errorf(errBetween, n, m)
+		}
+		return nil
+	}
+}
+
+// Checks that a value is a member of the given choices.
+func In[T comparable](choices ...T) Check[T] {
+	return func(v TypedField[T]) error {
+		if !slices.Contains(choices, v.Get()) {
+			return errInvalidChoice
+		}
+		return nil
+	}
+}
+
+// Checks that a value passes the func, or returns an error with the message.
+func Func[T any](fn func(T) bool, errMsg string) Check[T] {
+	err := errors.New(errMsg)
+
+	return func(v TypedField[T]) error {
+		if !fn(v.Get()) {
+			return err
+		}
+		return nil
+	}
+}
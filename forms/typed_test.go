@@ -0,0 +1,141 @@
+package forms
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/tunedmystic/rio/assert"
+	"github.com/tunedmystic/rio/decimal"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Type: TypedField
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestClean(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		form := New()
+		Clean(form, "age", "30", strconv.Atoi, Required[int](), Gte(18))
+		Clean(form, "price", "19.99", decimal.Parse, Required[decimal.Decimal]())
+		Clean(form, "name", "", func(s string) (string, error) { return s, nil })
+
+		assert.Equal(t, form.IsValid(), true)
+		assert.Equal(t, Cleaned[int](form, "age"), 30)
+		assert.Equal(t, Cleaned[decimal.Decimal](form, "price").String(), "19.99")
+		assert.Equal(t, Cleaned[string](form, "name"), "")
+		assert.Equal(t, form.MustField("name").IsBlank(), true)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		form := New()
+		Clean(form, "age", "12", strconv.Atoi, Gte(18))
+		Clean(form, "count", "abc", strconv.Atoi)
+		Clean(form, "size", "", strconv.Atoi, Required[int]())
+
+		assert.Equal(t, form.IsValid(), false)
+		assert.Equal(t, form.MustField("age").Err().Error(), "must be more than or equal to 18")
+		assert.Equal(t, form.MustField("count").Err(), error(errParseValue))
+		assert.Equal(t, form.MustField("size").Err(), errBlankValue)
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		form := New()
+		Clean(form, "age", "x", func(string) (int, error) { return 0, errParseInt })
+		assert.Equal(t, form.MustField("age").Err(), error(errParseInt))
+	})
+
+	t.Run("Cleaned with the wrong type", func(t *testing.T) {
+		form := New()
+		Clean(form, "age", "30", strconv.Atoi)
+		assert.Panic(t, func() { Cleaned[string](form, "age") })
+	})
+
+	t.Run("Cleaned with the Clean methods", func(t *testing.T) {
+		form := New()
+		form.CleanInteger("age", "30")
+		form.CleanDecimal("price", "19.99")
+		form.CleanBytes("size", "2KB")
+		form.CleanString("name", "")
+
+		assert.Equal(t, Cleaned[int](form, "age"), 30)
+		assert.Equal(t, Cleaned[decimal.Decimal](form, "price").String(), "19.99")
+		assert.Equal(t, Cleaned[int64](form, "size"), form.CleanedBytes("size"))
+		assert.Equal(t, Cleaned[string](form, "name"), "")
+		assert.Panic(t, func() { Cleaned[string](form, "age") })
+		assert.Panic(t, func() { Cleaned[int](form, "size") })
+	})
+}
+
+type testStatus string
+
+const (
+	testDraft     testStatus = "draft"
+	testPublished testStatus = "published"
+)
+
+func TestCleanEnum(t *testing.T) {
+	statuses := []testStatus{testDraft, testPublished}
+
+	form := New()
+	CleanEnum(form, "status", " published ", statuses, Required[testStatus]())
+	CleanEnum(form, "bad", "archived", statuses)
+	CleanEnum(form, "blank", "", statuses)
+	CleanEnum(form, "required", "", statuses, Required[testStatus]())
+
+	assert.Equal(t, Cleaned[testStatus](form, "status"), testPublished)
+	assert.Equal(t, form.FieldError("bad"), "must be a valid choice")
+	assert.Equal(t, form.FieldError("blank"), "")
+	assert.Equal(t, Cleaned[testStatus](form, "blank"), testStatus(""))
+	assert.Equal(t, form.FieldError("required"), "cannot be blank")
+}
+
+func TestTypedCheckFuncs(t *testing.T) {
+	field := func(v int) TypedField[int] {
+		return TypedField[int]{val: strconv.Itoa(v), value: v}
+	}
+
+	tests := []struct {
+		check Check[int]
+		value int
+		want  string
+	}{
+		{Lt(5), 4, ""},
+		{Lt(5), 5, "must be less than 5"},
+		{Lte(5), 5, ""},
+		{Lte(5), 6, "must be less than or equal to 5"},
+		{Gt(5), 6, ""},
+		{Gt(5), 5, "must be more than 5"},
+		{Gte(5), 5, ""},
+		{Gte(5), 4, "must be more than or equal to 5"},
+		{Btw(1, 3), 2, ""},
+		{Btw(1, 3), 4, "must be between 1 and 3"},
+		{Btw(3, 3), 3, "invalid validation config"},
+		{In(1, 2), 2, ""},
+		{In(1, 2), 3, "must be a valid choice"},
+		{Func(func(n int) bool { return n%2 == 0 }, "must be even"), 3, "must be even"},
+	}
+
+	for _, tt := range tests {
+		err := tt.check(field(tt.value))
+		if tt.want == "" {
+			assert.Nil(t, err)
+			continue
+		}
+		assert.Equal(t, err.Error(), tt.want)
+	}
+
+	t.Run("strings", func(t *testing.T) {
+		check := Lt("m")
+		assert.Nil(t, check(TypedField[string]{value: "apple"}))
+		assert.Equal(t, strings.HasPrefix(check(TypedField[string]{value: "zoo"}).Error(), "must be less than"), true)
+	})
+}
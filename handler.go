@@ -21,7 +21,7 @@ import (
 // .
 func BasicHttp(msg string) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
-		http.Error(w, msg, http.StatusOK)
+		HttpStatus(w, http.StatusOK, msg)
 	}
 	return http.HandlerFunc(fn)
 }
@@ -34,7 +34,7 @@ func BasicHttp(msg string) http.Handler {
 // .
 func BasicJson(msg string) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
-		writeJson(w, msg, http.StatusOK)
+		JsonStatus(w, http.StatusOK, msg)
 	}
 	return http.HandlerFunc(fn)
 }
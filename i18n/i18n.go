@@ -0,0 +1,374 @@
+// Package i18n implements message catalogs, locale negotiation
+// and translation functions for templates.
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Bundle
+//
+//
+// ------------------------------------------------------------------
+
+// Bundle is a collection of message catalogs, one per locale.
+//
+// Catalogs are json files named by their locale, like "en.json" or "pt-BR.json".
+// A message is either a string, or an object of plural forms.
+//
+//	{
+//		"greeting": "Hello, %s!",
+//		"items": {"zero": "No items", "one": "%d item", "other": "%d items"}
+//	}
+//
+// .
+type Bundle struct {
+	fallback string
+	catalogs map[string]map[string]message
+}
+
+// message is a translated message, with optional plural forms.
+type message struct {
+	Other string
+	One   string
+	Zero  string
+}
+
+// UnmarshalJSON parses a message from a string or an object of plural forms.
+func (m *message) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		m.Other = s
+		return nil
+	}
+
+	var forms struct {
+		Zero  string `json:"zero"`
+		One   string `json:"one"`
+		Other string `json:"other"`
+	}
+	if err := json.Unmarshal(b, &forms); err != nil {
+		return err
+	}
+	m.Zero, m.One, m.Other = forms.Zero, forms.One, forms.Other
+	return nil
+}
+
+// Load constructs a Bundle from the json catalogs in the root of fsys.
+//
+// The fallback locale is used when a locale, or a message within
+// a locale, does not exist.
+func Load(fsys fs.FS, fallback string) (*Bundle, error) {
+	b := &Bundle{
+		fallback: fallback,
+		catalogs: make(map[string]map[string]message),
+	}
+
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+
+		catalog := make(map[string]message)
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("failed to parse catalog %s: %w", file, err)
+		}
+
+		locale := strings.TrimSuffix(path.Base(file), ".json")
+		b.catalogs[locale] = catalog
+	}
+
+	if _, ok := b.catalogs[fallback]; !ok {
+		return nil, fmt.Errorf("missing catalog for fallback locale %q", fallback)
+	}
+
+	return b, nil
+}
+
+// Locales returns the locales of the Bundle, sorted.
+func (b *Bundle) Locales() []string {
+	locales := make([]string, 0, len(b.catalogs))
+	for locale := range b.catalogs {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// T translates the message key for the locale.
+//
+// If args are given, then the message is formatted with fmt.Sprintf.
+// If the message does not exist, then the key is returned.
+func (b *Bundle) T(locale, key string, args ...any) string {
+	m, ok := b.lookup(locale, key)
+	if !ok {
+		return key
+	}
+	return format(m.Other, args)
+}
+
+// TN translates the plural message key for the locale, choosing the
+// plural form by the count n.
+//
+// The message is formatted with fmt.Sprintf, with n as the first argument.
+func (b *Bundle) TN(locale, key string, n int, args ...any) string {
+	m, ok := b.lookup(locale, key)
+	if !ok {
+		return key
+	}
+
+	msg := m.Other
+	switch {
+	case n == 0 && m.Zero != "":
+		msg = m.Zero
+	case n == 1 && m.One != "":
+		msg = m.One
+	}
+	return format(msg, append([]any{n}, args...))
+}
+
+// lookup finds the message in the locale's catalog, then in the
+// catalog of the locale's base language, then in the fallback catalog.
+func (b *Bundle) lookup(locale, key string) (message, bool) {
+	for _, l := range []string{locale, baseLanguage(locale), b.fallback} {
+		if m, ok := b.catalogs[l][key]; ok {
+			return m, true
+		}
+	}
+	return message{}, false
+}
+
+// format formats the message, if there are args and formatting verbs.
+//
+// Args which the message does not use are dropped, so that a translation
+// which leaves out a placeholder does not end with "%!(EXTRA ...)".
+func format(msg string, args []any) string {
+	if len(args) == 0 || !strings.Contains(msg, "%") {
+		return msg
+	}
+	if n := argCount(msg); n < len(args) {
+		args = args[:n]
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// argCount returns the number of args used by the formatting verbs of
+// the message, which is the highest arg used, including explicit
+// indexes like "%[2]s", and "*" widths and precisions.
+func argCount(msg string) int {
+	next, count := 0, 0
+
+	for i := 0; i < len(msg); i++ {
+		if msg[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(msg) && msg[i] == '%' {
+			continue
+		}
+
+		// An explicit index, like "[2]", sets the next arg.
+		index := func() {
+			if i >= len(msg) || msg[i] != '[' {
+				return
+			}
+			end := strings.IndexByte(msg[i:], ']')
+			if end < 0 {
+				return
+			}
+			if n, err := strconv.Atoi(msg[i+1 : i+end]); err == nil && n > 0 {
+				next = n - 1
+			}
+			i += end + 1
+		}
+		// A width or precision is either digits, or a "*" which uses an arg.
+		number := func() {
+			if i < len(msg) && msg[i] == '*' {
+				next++
+				count = max(count, next)
+				i++
+				return
+			}
+			for i < len(msg) && msg[i] >= '0' && msg[i] <= '9' {
+				i++
+			}
+		}
+
+		for i < len(msg) && strings.IndexByte("+-# 0", msg[i]) >= 0 {
+			i++
+		}
+		index()
+		number()
+		if i < len(msg) && msg[i] == '.' {
+			i++
+			index()
+			number()
+		}
+		index()
+
+		// The verb uses an arg.
+		if i < len(msg) {
+			next++
+			count = max(count, next)
+		}
+	}
+
+	return count
+}
+
+// baseLanguage returns the language of a locale, like "pt" for "pt-BR".
+func baseLanguage(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Locale Negotiation
+//
+//
+// ------------------------------------------------------------------
+
+// Match returns the best supported locale for an Accept-Language header.
+// If no locale matches, then the fallback locale is returned.
+func (b *Bundle) Match(acceptLanguage string) string {
+	type langQ struct {
+		lang string
+		q    float64
+	}
+
+	var langs []langQ
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		lang, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if val, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(val, 64); err == nil {
+				q = f
+			}
+		}
+		if lang != "" && q > 0 {
+			langs = append(langs, langQ{lang, q})
+		}
+	}
+
+	slices.SortStableFunc(langs, func(a, b langQ) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	for _, l := range langs {
+		if locale, ok := b.supported(l.lang); ok {
+			return locale
+		}
+	}
+	return b.fallback
+}
+
+// supported returns the catalog locale which matches the language tag,
+// comparing case-insensitively, and falling back to the base language.
+func (b *Bundle) supported(tag string) (string, bool) {
+	for _, candidate := range []string{tag, baseLanguage(tag)} {
+		for locale := range b.catalogs {
+			if strings.EqualFold(locale, candidate) {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
+
+// localeKey is the context key of the request locale.
+type localeKey struct{}
+
+// WithLocale returns a copy of the context with the locale.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// Locale returns the locale of the context, or "" if there is none.
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// Middleware is a middleware which negotiates the locale of the request,
+// and stores it in the request context.
+//
+// The locale is read from the named cookie, if it is a supported locale.
+// Otherwise, it is negotiated from the Accept-Language header.
+func (b *Bundle) Middleware(cookieName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			locale := ""
+			if c, err := r.Cookie(cookieName); err == nil {
+				locale, _ = b.supported(c.Value)
+			}
+			if locale == "" {
+				locale = b.Match(r.Header.Get("Accept-Language"))
+			}
+
+			w.Header().Add("Vary", "Accept-Language")
+			next.ServeHTTP(w, r.WithContext(WithLocale(r.Context(), locale)))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Template Functions
+//
+//
+// ------------------------------------------------------------------
+
+// FuncMap returns the "t" and "tn" template functions.
+// Both take the locale as the first argument.
+//
+//	{{ t .Ctx.locale "greeting" .Data.Name }}
+//	{{ tn .Ctx.locale "items" .Data.Count }}
+//
+// .
+func (b *Bundle) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"t":  b.T,
+		"tn": b.TN,
+	}
+}
+
+// RequestContext returns a func which exposes the request
+// locale to templates as the "locale" value.
+// It can be used with rio.WithRequestContext.
+func (b *Bundle) RequestContext() func(*http.Request) map[string]any {
+	return func(r *http.Request) map[string]any {
+		locale := Locale(r.Context())
+		if locale == "" {
+			locale = b.fallback
+		}
+		return map[string]any{"locale": locale}
+	}
+}
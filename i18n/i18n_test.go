@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/tunedmystic/rio/assert"
+)
+
+var catalogs = fstest.MapFS{
+	"en.json":    {Data: []byte(`{"greeting": "Hello, %s!", "items": {"zero": "No items", "one": "%d item", "other": "%d items"}, "bye": "Bye"}`)},
+	"fr.json":    {Data: []byte(`{"greeting": "Bonjour, %s !", "items": {"one": "%d article", "other": "%d articles"}}`)},
+	"pt-BR.json": {Data: []byte(`{"greeting": "Olá, %s!"}`)},
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Bundle
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestBundle(t *testing.T) {
+	b, err := Load(catalogs, "en")
+	assert.Equal(t, err, nil)
+
+	t.Run("Load-missing-fallback", func(t *testing.T) {
+		_, err := Load(catalogs, "de")
+		assert.Equal(t, err.Error(), `missing catalog for fallback locale "de"`)
+	})
+
+	t.Run("Locales", func(t *testing.T) {
+		assert.Equal(t, b.Locales(), []string{"en", "fr", "pt-BR"})
+	})
+
+	t.Run("T", func(t *testing.T) {
+		assert.Equal(t, b.T("fr", "greeting", "Alice"), "Bonjour, Alice !")
+		assert.Equal(t, b.T("fr", "bye"), "Bye")
+		assert.Equal(t, b.T("pt-BR", "greeting", "Ana"), "Olá, Ana!")
+		assert.Equal(t, b.T("en", "missing"), "missing")
+	})
+
+	t.Run("TN", func(t *testing.T) {
+		assert.Equal(t, b.TN("en", "items", 0), "No items")
+		assert.Equal(t, b.TN("en", "items", 1), "1 item")
+		assert.Equal(t, b.TN("en", "items", 5), "5 items")
+		assert.Equal(t, b.TN("fr", "items", 0), "0 articles")
+	})
+
+	t.Run("format-extra-args", func(t *testing.T) {
+		tests := []struct {
+			msg  string
+			args []any
+			want string
+		}{
+			{"Hello, %s", []any{"Alice", 3}, "Hello, Alice"},
+			{"Bonjour !", []any{"Alice"}, "Bonjour !"},
+			{"100%% off %s", []any{"today", "now"}, "100% off today"},
+			{"%[2]s, %[1]s", []any{"a", "b", "c"}, "b, a"},
+			{"%*d|", []any{4, 7, 9}, "   7|"},
+			{"%.*f", []any{1, 2.25, "x"}, "2.2"},
+			{"%s and %s", []any{"a"}, "a and %!s(MISSING)"},
+		}
+
+		for _, tt := range tests {
+			assert.Equal(t, format(tt.msg, tt.args), tt.want)
+		}
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		assert.Equal(t, b.Match("fr-CA,fr;q=0.9,en;q=0.8"), "fr")
+		assert.Equal(t, b.Match("de, pt-br;q=0.5"), "pt-BR")
+		assert.Equal(t, b.Match("en;q=0.1, fr;q=0.7"), "fr")
+		assert.Equal(t, b.Match("de"), "en")
+		assert.Equal(t, b.Match(""), "en")
+	})
+
+	t.Run("Middleware", func(t *testing.T) {
+		var got string
+		h := b.Middleware("lang")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = Locale(r.Context())
+		}))
+
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Language", "fr")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+		assert.Equal(t, got, "fr")
+
+		r.AddCookie(&http.Cookie{Name: "lang", Value: "pt-BR"})
+		h.ServeHTTP(httptest.NewRecorder(), r)
+		assert.Equal(t, got, "pt-BR")
+	})
+}
@@ -0,0 +1,235 @@
+package rio
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: RotatingFile
+//
+//
+// ------------------------------------------------------------------
+
+// backupTimeFormat is the timestamp format of rotated log files.
+const backupTimeFormat = "20060102T150405.000"
+
+// RotatingFile is an io.Writer which writes logs to a file, and rotates
+// the file when it gets too large or too old.
+//
+// A rotated file is renamed with a timestamp, like "app-20240102T150405.000.log",
+// and the oldest rotated files are removed once there are more than MaxBackups.
+//
+//	logFile := &RotatingFile{
+//		Filename:   "logs/app.log",
+//		MaxSize:    10 << 20,
+//		MaxAge:     24 * time.Hour,
+//		MaxBackups: 7,
+//	}
+//	defer logFile.Close()
+//	Logger(NewJsonLogger(logFile))
+//
+// .
+type RotatingFile struct {
+	// Filename is the path of the log file.
+	Filename string
+
+	// MaxSize is the max size of the file, in bytes.
+	// Zero means no limit.
+	MaxSize int64
+
+	// MaxAge is how long to write to a file before it is rotated.
+	// Zero means no limit.
+	MaxAge time.Duration
+
+	// MaxBackups is the number of rotated files to keep.
+	// Zero keeps all rotated files.
+	MaxBackups int
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// Write writes to the log file, rotating it first if needed.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	tooLarge := f.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.MaxSize
+	tooOld := f.MaxAge > 0 && time.Since(f.opened) >= f.MaxAge
+	if tooLarge || tooOld {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate closes the current log file, renames it, and opens a new one.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	return f.rotate()
+}
+
+// Close closes the log file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// open opens the log file for appending, creating it if needed.
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.Filename), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.Filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.opened = time.Now()
+	return nil
+}
+
+// rotate renames the current log file with a timestamp, opens a new one,
+// and removes the oldest rotated files.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	if err := os.Rename(f.Filename, f.backupName(time.Now())); err != nil {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	return f.removeOldBackups()
+}
+
+// backupName returns the name of a rotated log file.
+//
+// If a file with the timestamp already exists, because the file was
+// rotated twice within a millisecond, then a counter is appended,
+// like "app-20240102T150405.000-1.log", so that it is not overwritten.
+func (f *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.Filename)
+	base := strings.TrimSuffix(f.Filename, ext)
+	stamp := t.Format(backupTimeFormat)
+
+	name := base + "-" + stamp + ext
+	for n := 1; fileExists(name); n++ {
+		name = base + "-" + stamp + "-" + strconv.Itoa(n) + ext
+	}
+	return name
+}
+
+// fileExists checks if a file exists with the name.
+func fileExists(name string) bool {
+	_, err := os.Lstat(name)
+	return err == nil
+}
+
+// parseBackupStamp parses the timestamp and counter of a rotated file.
+func parseBackupStamp(stamp string) (time.Time, int, bool) {
+	n := 0
+	if i := strings.LastIndex(stamp, "-"); i >= 0 {
+		c, err := strconv.Atoi(stamp[i+1:])
+		if err != nil || c < 1 {
+			return time.Time{}, 0, false
+		}
+		stamp, n = stamp[:i], c
+	}
+
+	t, err := time.Parse(backupTimeFormat, stamp)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return t, n, true
+}
+
+// removeOldBackups removes the oldest rotated files, keeping MaxBackups.
+func (f *RotatingFile) removeOldBackups() error {
+	if f.MaxBackups <= 0 {
+		return nil
+	}
+
+	ext := filepath.Ext(f.Filename)
+	base := strings.TrimSuffix(f.Filename, ext)
+
+	matches, err := filepath.Glob(base + "-*" + ext)
+	if err != nil {
+		return err
+	}
+
+	// Only consider the files which were named by rotate.
+	type backup struct {
+		name string
+		t    time.Time
+		n    int
+	}
+	var backups []backup
+	for _, name := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, base+"-"), ext)
+		if t, n, ok := parseBackupStamp(stamp); ok {
+			backups = append(backups, backup{name, t, n})
+		}
+	}
+
+	// Sort chronologically, by the timestamp and then the counter.
+	slices.SortFunc(backups, func(a, b backup) int {
+		if c := a.t.Compare(b.t); c != 0 {
+			return c
+		}
+		return a.n - b.n
+	})
+	for len(backups) > f.MaxBackups {
+		if err := os.Remove(backups[0].name); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
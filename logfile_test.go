@@ -0,0 +1,95 @@
+package rio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// RotatingFile
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestRotatingFile(t *testing.T) {
+	t.Run("MaxSize", func(t *testing.T) {
+		dir := t.TempDir()
+		f := &RotatingFile{
+			Filename:   filepath.Join(dir, "logs", "app.log"),
+			MaxSize:    10,
+			MaxBackups: 2,
+		}
+		defer f.Close()
+
+		// Keep a file which is not a backup.
+		os.MkdirAll(filepath.Join(dir, "logs"), 0o755)
+		os.WriteFile(filepath.Join(dir, "logs", "app-other.log"), nil, 0o644)
+
+		for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+			_, err := f.Write([]byte(line))
+			assert(t, err, nil)
+			time.Sleep(2 * time.Millisecond)
+		}
+
+		current, _ := os.ReadFile(f.Filename)
+		assert(t, string(current), "line 4\n")
+
+		backups, _ := filepath.Glob(filepath.Join(dir, "logs", "app-2*.log"))
+		assert(t, len(backups), 2)
+
+		oldest, _ := os.ReadFile(backups[0])
+		assert(t, string(oldest), "line 2\n")
+
+		_, err := os.Stat(filepath.Join(dir, "logs", "app-other.log"))
+		assert(t, err, nil)
+	})
+
+	t.Run("MaxAge", func(t *testing.T) {
+		dir := t.TempDir()
+		f := &RotatingFile{
+			Filename: filepath.Join(dir, "app.log"),
+			MaxAge:   time.Millisecond,
+		}
+		defer f.Close()
+
+		f.Write([]byte("old\n"))
+		time.Sleep(2 * time.Millisecond)
+		f.Write([]byte("new\n"))
+
+		current, _ := os.ReadFile(f.Filename)
+		assert(t, string(current), "new\n")
+
+		backups, _ := filepath.Glob(filepath.Join(dir, "app-*.log"))
+		assert(t, len(backups), 1)
+	})
+
+	t.Run("same millisecond", func(t *testing.T) {
+		dir := t.TempDir()
+		f := &RotatingFile{
+			Filename:   filepath.Join(dir, "app.log"),
+			MaxBackups: 2,
+		}
+		now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+		for _, want := range []string{"app-20240102T150405.000.log", "app-20240102T150405.000-1.log", "app-20240102T150405.000-2.log"} {
+			name := f.backupName(now)
+			assert(t, filepath.Base(name), want)
+			os.WriteFile(name, []byte(want), 0o644)
+		}
+
+		assert(t, f.removeOldBackups(), nil)
+		_, err := os.Stat(filepath.Join(dir, "app-20240102T150405.000.log"))
+		assert(t, os.IsNotExist(err), true)
+
+		backups, _ := filepath.Glob(filepath.Join(dir, "app-*.log"))
+		assert(t, len(backups), 2)
+	})
+}
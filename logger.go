@@ -2,9 +2,17 @@ package rio
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // ------------------------------------------------------------------
@@ -17,32 +25,488 @@ import (
 
 var defaultLogger = NewLogger(os.Stdout)
 
+// logLevel is the level of loggers constructed with NewLogger.
+// It defaults to the LOG_LEVEL env var, or info.
+var logLevel = levelFromEnv("LOG_LEVEL")
+
 // Logger sets the default logger to the given slog.Logger.
+//
+// The default logger is used by the Log* helpers and by all of
+// rio's middleware, so it is the one place to configure logging.
 func Logger(l *slog.Logger) {
 	defaultLogger = l
 }
 
+// DefaultLogger returns the default logger, so that code outside of rio
+// can log with the same configuration as the middleware.
+func DefaultLogger() *slog.Logger {
+	return defaultLogger
+}
+
 // NewLogger constructs and returns a new *slog.Logger.
-func NewLogger(w io.Writer) *slog.Logger {
-	return slog.New(slog.NewTextHandler(w, nil))
+//
+// By default, the logger writes text, and its level can be changed
+// at runtime with SetLogLevel. The output format defaults to the
+// LOG_FORMAT env var, which can be "text", "json" or "dev".
+//
+//	NewLogger(os.Stdout, WithJsonOutput(), WithLevel(slog.LevelWarn))
+//
+// .
+func NewLogger(w io.Writer, opts ...LoggerOpt) *slog.Logger {
+	cfg := loggerConfig{level: logLevel, format: os.Getenv("LOG_FORMAT")}
+	for i := range opts {
+		opts[i](&cfg)
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: cfg.level, AddSource: cfg.addSource}
+
+	var h slog.Handler
+	switch cfg.format {
+	case "json":
+		h = slog.NewJSONHandler(w, handlerOpts)
+	case "dev":
+		h = NewDevHandler(w, handlerOpts, os.Getenv("NO_COLOR") == "")
+	default:
+		h = slog.NewTextHandler(w, handlerOpts)
+	}
+	extractors := append([]ContextExtractor{requestAttrs}, cfg.extractors...)
+	return slog.New(ContextHandler(RedactHandler(h), extractors...))
+}
+
+// NewLoggerLevel constructs and returns a new *slog.Logger,
+// which logs records at or above the given level.
+func NewLoggerLevel(w io.Writer, level slog.Leveler) *slog.Logger {
+	return NewLogger(w, WithLevel(level))
+}
+
+// NewJsonLogger constructs and returns a new *slog.Logger, which writes json.
+// This is useful in production, where logs are ingested by machines.
+func NewJsonLogger(w io.Writer, opts ...LoggerOpt) *slog.Logger {
+	// Concat copies the opts, so that the caller's slice is not modified.
+	return NewLogger(w, slices.Concat(opts, []LoggerOpt{WithJsonOutput()})...)
+}
+
+// SetLogLevel changes the level of loggers constructed with NewLogger.
+// This takes effect immediately, which is useful for live debugging.
+func SetLogLevel(level slog.Level) {
+	logLevel.Set(level)
+}
+
+// levelFromEnv returns a level parsed from the env var, like "debug"
+// or "WARN". It defaults to info if the env var is unset or invalid.
+func levelFromEnv(key string) *slog.LevelVar {
+	level := new(slog.LevelVar)
+	if val := os.Getenv(key); val != "" {
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(val)); err == nil {
+			level.Set(l)
+		}
+	}
+	return level
+}
+
+// LoggerFrom returns the default logger, with the request_id and
+// trace_id attributes of the context, if any.
+//
+// This is useful to pass a request-scoped logger to code which
+// does not have the request context.
+func LoggerFrom(ctx context.Context) *slog.Logger {
+	attrs := requestAttrs(ctx)
+	if len(attrs) == 0 {
+		return defaultLogger
+	}
+
+	args := make([]any, len(attrs))
+	for i := range attrs {
+		args[i] = attrs[i]
+	}
+	return defaultLogger.With(args...)
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Functional Options for Logger
+//
+//
+// ------------------------------------------------------------------
+
+// LoggerOpt is a function to configure a logger constructed with NewLogger.
+type LoggerOpt func(*loggerConfig)
+
+// loggerConfig is the configuration of a logger.
+type loggerConfig struct {
+	level      slog.Leveler
+	format     string
+	addSource  bool
+	extractors []ContextExtractor
+}
+
+// WithLevel sets the minimum level of the logger.
+func WithLevel(level slog.Leveler) LoggerOpt {
+	return func(c *loggerConfig) {
+		c.level = level
+	}
+}
+
+// WithSource adds the source file and line of the logging code to each record.
+func WithSource() LoggerOpt {
+	return func(c *loggerConfig) {
+		c.addSource = true
+	}
+}
+
+// WithContextAttrs adds attributes derived from the context to each record.
+// The request_id and trace_id attributes are always added.
+func WithContextAttrs(extractors ...ContextExtractor) LoggerOpt {
+	return func(c *loggerConfig) {
+		c.extractors = append(c.extractors, extractors...)
+	}
+}
+
+// WithJsonOutput makes the logger write json instead of text.
+func WithJsonOutput() LoggerOpt {
+	return func(c *loggerConfig) {
+		c.format = "json"
+	}
+}
+
+// WithDevOutput makes the logger write colorized, human-friendly text,
+// for local development. Colors are disabled if the NO_COLOR env var is set.
+func WithDevOutput() LoggerOpt {
+	return func(c *loggerConfig) {
+		c.format = "dev"
+	}
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Logging Helpers
+//
+//
+// ------------------------------------------------------------------
+
+// logStacks enables stack traces on logged errors.
+var logStacks atomic.Bool
+
+// LogStackTraces enables or disables attaching the call stack, as
+// the "stack" attribute, to errors logged with LogError and LogErrorCtx.
+func LogStackTraces(enabled bool) {
+	logStacks.Store(enabled)
 }
 
 // LogDebug logs a debug message.
 func LogDebug(msg string, attrs ...slog.Attr) {
-	defaultLogger.LogAttrs(context.Background(), slog.LevelDebug, msg, attrs...)
+	logAttrs(context.Background(), 1, slog.LevelDebug, msg, attrs...)
 }
 
 // LogInfo logs an info message.
 func LogInfo(msg string, attrs ...slog.Attr) {
-	defaultLogger.LogAttrs(context.Background(), slog.LevelInfo, msg, attrs...)
+	logAttrs(context.Background(), 1, slog.LevelInfo, msg, attrs...)
 }
 
 // LogWarn logs a warning message.
 func LogWarn(msg string, attrs ...slog.Attr) {
-	defaultLogger.LogAttrs(context.Background(), slog.LevelWarn, msg, attrs...)
+	logAttrs(context.Background(), 1, slog.LevelWarn, msg, attrs...)
+}
+
+// LogDebugCtx logs a debug message with the context.
+func LogDebugCtx(ctx context.Context, msg string, attrs ...slog.Attr) {
+	logAttrs(ctx, 1, slog.LevelDebug, msg, attrs...)
+}
+
+// LogInfoCtx logs an info message with the context.
+func LogInfoCtx(ctx context.Context, msg string, attrs ...slog.Attr) {
+	logAttrs(ctx, 1, slog.LevelInfo, msg, attrs...)
+}
+
+// LogWarnCtx logs a warning message with the context.
+func LogWarnCtx(ctx context.Context, msg string, attrs ...slog.Attr) {
+	logAttrs(ctx, 1, slog.LevelWarn, msg, attrs...)
 }
 
 // LogError logs an error.
 func LogError(err error, attrs ...slog.Attr) {
-	defaultLogger.LogAttrs(context.Background(), slog.LevelError, err.Error(), attrs...)
+	logError(context.Background(), err, attrs...)
+}
+
+// LogErrorCtx logs an error with the context, so that the
+// request attributes of the context are included.
+func LogErrorCtx(ctx context.Context, err error, attrs ...slog.Attr) {
+	logError(ctx, err, attrs...)
+}
+
+// logError logs an error, with the call stack if enabled.
+// It must be called directly by LogError or LogErrorCtx.
+func logError(ctx context.Context, err error, attrs ...slog.Attr) {
+	if logStacks.Load() {
+		attrs = append(attrs, slog.String("stack", callStack(4)))
+	}
+	logAttrs(ctx, 2, slog.LevelError, err.Error(), attrs...)
+}
+
+// logAttrs logs a message with the context, using the default logger.
+//
+// The skip is the number of callers between logAttrs and the code which
+// is logging, so that the record's source location points at that code.
+func logAttrs(ctx context.Context, skip int, level slog.Level, msg string, attrs ...slog.Attr) {
+	if !defaultLogger.Enabled(ctx, level) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(skip+2, pcs[:])
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.AddAttrs(attrs...)
+	defaultLogger.Handler().Handle(ctx, r)
+}
+
+// callStack returns the call stack, skipping the given number of frames.
+func callStack(skip int) string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Context Log Handler
+//
+//
+// ------------------------------------------------------------------
+
+// ContextExtractor returns attributes derived from the values of a context.
+type ContextExtractor func(ctx context.Context) []slog.Attr
+
+// ContextValue returns a ContextExtractor which adds the value stored
+// in the context with the given key, as an attribute with the given name.
+//
+//	NewLogger(os.Stdout, WithContextAttrs(
+//		ContextValue("user_id", userIdKey{}),
+//		ContextValue("tenant", tenantKey{}),
+//	))
+//
+// .
+func ContextValue(name string, key any) ContextExtractor {
+	return func(ctx context.Context) []slog.Attr {
+		if val := ctx.Value(key); val != nil {
+			return []slog.Attr{slog.Any(name, val)}
+		}
+		return nil
+	}
+}
+
+// ContextHandler returns a slog.Handler which adds the attributes of the
+// extractors to each record, before passing it to the given handler.
+//
+// Records must be logged with a context for the attributes to be found,
+// like with LogInfoCtx or slog.Logger.InfoContext.
+func ContextHandler(h slog.Handler, extractors ...ContextExtractor) slog.Handler {
+	return &contextHandler{h, extractors}
+}
+
+// contextHandler is a slog.Handler which adds attributes from the context.
+type contextHandler struct {
+	slog.Handler
+	extractors []ContextExtractor
+}
+
+// Handle adds the context attributes, and passes the record to the wrapped handler.
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx == nil {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	var attrs []slog.Attr
+	for _, extract := range h.extractors {
+		attrs = append(attrs, extract(ctx)...)
+	}
+	if len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new contextHandler, wrapping the handler with the attributes.
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{h.Handler.WithAttrs(attrs), h.extractors}
+}
+
+// WithGroup returns a new contextHandler, wrapping the handler with the group.
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{h.Handler.WithGroup(name), h.extractors}
+}
+
+// requestAttrs returns the request_id and trace_id attributes of the context.
+func requestAttrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	if id := RequestIdFrom(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if id := TraceIdFrom(ctx); id != "" {
+		attrs = append(attrs, slog.String("trace_id", id))
+	}
+	return attrs
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Tee Log Handler
+//
+//
+// ------------------------------------------------------------------
+
+// TeeHandler returns a slog.Handler which passes each record to all
+// of the given handlers, like text to stdout and json to a file.
+//
+//	Logger(slog.New(TeeHandler(
+//		slog.NewTextHandler(os.Stdout, nil),
+//		slog.NewJSONHandler(logFile, nil),
+//	)))
+//
+// .
+func TeeHandler(handlers ...slog.Handler) slog.Handler {
+	return teeHandler(handlers)
+}
+
+// teeHandler is a slog.Handler which fans out records to several handlers.
+type teeHandler []slog.Handler
+
+// Enabled returns true if any of the handlers is enabled for the level.
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle passes the record to each handler which is enabled for its level.
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a new teeHandler, with the attributes added to each handler.
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+// WithGroup returns a new teeHandler, with the group added to each handler.
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Sampling Log Handler
+//
+//
+// ------------------------------------------------------------------
+
+// SamplingHandler returns a slog.Handler which samples identical records,
+// so that noisy code does not drown out the rest of the logs.
+//
+// Within each interval, the first record with a given level and message
+// is logged, followed by every nth identical record. Errors are always logged.
+//
+//	Logger(slog.New(SamplingHandler(handler, 100, time.Second)))
+//
+// .
+func SamplingHandler(h slog.Handler, n int, interval time.Duration) slog.Handler {
+	return &samplingHandler{
+		Handler: h,
+		sampler: &sampler{
+			n:        max(n, 1),
+			interval: interval,
+			counts:   make(map[sampleKey]int),
+		},
+	}
+}
+
+// samplingHandler is a slog.Handler which drops sampled out records.
+type samplingHandler struct {
+	slog.Handler
+	sampler *sampler
+}
+
+// Handle passes the record to the wrapped handler, if it is sampled.
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelError && !h.sampler.sample(r.Level, r.Message, r.Time) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a new samplingHandler, which shares the sampler.
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{h.Handler.WithAttrs(attrs), h.sampler}
+}
+
+// WithGroup returns a new samplingHandler, which shares the sampler.
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{h.Handler.WithGroup(name), h.sampler}
+}
+
+// sampleKey identifies identical records.
+type sampleKey struct {
+	level slog.Level
+	msg   string
+}
+
+// sampler counts identical records within an interval.
+type sampler struct {
+	n        int
+	interval time.Duration
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[sampleKey]int
+}
+
+// sample counts the record, and checks if it should be logged.
+func (s *sampler) sample(level slog.Level, msg string, t time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Reset the counts when the interval has passed.
+	if t.Sub(s.start) >= s.interval {
+		s.start = t
+		clear(s.counts)
+	}
+
+	key := sampleKey{level, msg}
+	count := s.counts[key]
+	s.counts[key] = count + 1
+	return count%s.n == 0
 }
@@ -0,0 +1,178 @@
+package rio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Logger
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestLogLevel(t *testing.T) {
+	t.Run("SetLogLevel", func(t *testing.T) {
+		defer SetLogLevel(logLevel.Level())
+
+		var buf bytes.Buffer
+		logger := NewLogger(&buf)
+
+		logger.Debug("hidden")
+		SetLogLevel(slog.LevelDebug)
+		logger.Debug("shown")
+
+		assert(t, strings.Contains(buf.String(), "hidden"), false)
+		assert(t, strings.Contains(buf.String(), "shown"), true)
+	})
+
+	t.Run("NewLoggerLevel", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewLoggerLevel(&buf, slog.LevelWarn)
+
+		logger.Info("hidden")
+		logger.Warn("shown")
+
+		assert(t, strings.Contains(buf.String(), "hidden"), false)
+		assert(t, strings.Contains(buf.String(), "shown"), true)
+	})
+
+	t.Run("levelFromEnv", func(t *testing.T) {
+		t.Setenv("TEST_LOG_LEVEL", "debug")
+		assert(t, levelFromEnv("TEST_LOG_LEVEL").Level(), slog.LevelDebug)
+
+		t.Setenv("TEST_LOG_LEVEL", "WARN")
+		assert(t, levelFromEnv("TEST_LOG_LEVEL").Level(), slog.LevelWarn)
+
+		t.Setenv("TEST_LOG_LEVEL", "bogus")
+		assert(t, levelFromEnv("TEST_LOG_LEVEL").Level(), slog.LevelInfo)
+	})
+}
+
+func TestJsonLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJsonLogger(&buf)
+
+	logger.Info("hello", slog.Int("status", 200))
+
+	assert(t, strings.Contains(buf.String(), `"msg":"hello","status":200}`), true)
+
+	// The caller's opts are not modified.
+	opts := make([]LoggerOpt, 1, 2)
+	opts[0] = WithLevel(slog.LevelDebug)
+	NewJsonLogger(&buf, opts...)
+	assert(t, opts[:2][1] == nil, true)
+}
+
+func TestTeeHandler(t *testing.T) {
+	var text, json bytes.Buffer
+	logger := slog.New(TeeHandler(
+		slog.NewTextHandler(&text, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		slog.NewJSONHandler(&json, &slog.HandlerOptions{Level: slog.LevelWarn}),
+	)).With(slog.String("app", "rio"))
+
+	logger.Debug("debug")
+	logger.Warn("warn")
+
+	assert(t, strings.Count(text.String(), "\n"), 2)
+	assert(t, strings.Contains(text.String(), "msg=warn app=rio"), true)
+	assert(t, strings.Count(json.String(), "\n"), 1)
+	assert(t, strings.Contains(json.String(), `"msg":"warn","app":"rio"`), true)
+}
+
+func TestLogError(t *testing.T) {
+	defer Logger(defaultLogger)
+
+	t.Run("WithSource", func(t *testing.T) {
+		var buf bytes.Buffer
+		Logger(NewLogger(&buf, WithSource()))
+
+		LogWarn("careful")
+
+		assert(t, strings.Contains(buf.String(), "logger_test.go:"), true)
+	})
+
+	t.Run("LogStackTraces", func(t *testing.T) {
+		defer LogStackTraces(false)
+
+		var buf bytes.Buffer
+		Logger(NewJsonLogger(&buf))
+
+		LogError(errors.New("no stack"))
+		LogStackTraces(true)
+		LogError(errors.New("with stack"))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert(t, strings.Contains(lines[0], `"stack"`), false)
+		assert(t, strings.Contains(lines[1], `"stack":"github.com/tunedmystic/rio.TestLogError.func2`), true)
+	})
+
+	t.Run("LogErrorCtx", func(t *testing.T) {
+		var buf bytes.Buffer
+		Logger(NewLogger(&buf))
+
+		ctx := context.WithValue(context.Background(), requestIdKey{}, requestIds{requestId: "req-1"})
+		LogErrorCtx(ctx, errors.New("failed"))
+
+		assert(t, strings.Contains(buf.String(), "msg=failed request_id=req-1"), true)
+	})
+}
+
+func TestSamplingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(SamplingHandler(slog.NewTextHandler(&buf, nil), 3, time.Hour))
+
+	for range 7 {
+		logger.Warn("noisy")
+		logger.Info("other")
+		logger.Error("failed")
+	}
+
+	assert(t, strings.Count(buf.String(), "msg=noisy"), 3)
+	assert(t, strings.Count(buf.String(), "msg=other"), 3)
+	assert(t, strings.Count(buf.String(), "msg=failed"), 7)
+}
+
+func TestContextHandler(t *testing.T) {
+	defer Logger(defaultLogger)
+
+	type userKey struct{}
+	type tenantKey struct{}
+
+	var buf bytes.Buffer
+	Logger(NewLogger(&buf, WithContextAttrs(
+		ContextValue("user_id", userKey{}),
+		ContextValue("tenant", tenantKey{}),
+	)))
+
+	ctx := context.WithValue(context.Background(), requestIdKey{}, requestIds{requestId: "req-1"})
+	ctx = context.WithValue(ctx, userKey{}, 42)
+
+	LogInfoCtx(ctx, "hello")
+	LogInfo("plain")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert(t, strings.HasSuffix(lines[0], "msg=hello request_id=req-1 user_id=42"), true)
+	assert(t, strings.HasSuffix(lines[1], "msg=plain"), true)
+}
+
+func TestDefaultLogger(t *testing.T) {
+	defer Logger(defaultLogger)
+
+	logger := NewLogger(io.Discard)
+	Logger(logger)
+
+	assert(t, DefaultLogger(), logger)
+}
@@ -0,0 +1,167 @@
+package rio
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Markdown
+//
+//
+// ------------------------------------------------------------------
+
+// Markdown renders markdown to an HTML fragment.
+//
+// Raw HTML within the markdown is escaped, and links are only kept
+// if they are relative, or use the http, https or mailto schemes.
+// This makes it safe to render user-provided content.
+//
+// The supported syntax is: headings, paragraphs, emphasis, strong,
+// inline code, fenced code blocks, lists, blockquotes, links and
+// horizontal rules.
+func Markdown(src string) template.HTML {
+	return template.HTML(renderMarkdown(src))
+}
+
+var (
+	mdHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRule        = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	mdUnordered   = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	mdOrdered     = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdStrong      = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdEmphasis    = regexp.MustCompile(`\*(.+?)\*`)
+	mdSafeScheme  = regexp.MustCompile(`^(?i)(https?:|mailto:|[^:]*$)`)
+	mdPlaceholder = regexp.MustCompile(`\x00(\d+|/)\x00`)
+)
+
+// renderMarkdown renders the markdown blocks of src to html.
+func renderMarkdown(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	var para []string
+
+	flushPara := func() {
+		if len(para) > 0 {
+			b.WriteString("<p>" + renderInline(strings.Join(para, "\n")) + "</p>\n")
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flushPara()
+
+		case strings.HasPrefix(trimmed, "```"):
+			flushPara()
+			lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			if lang != "" {
+				b.WriteString(`<pre><code class="language-` + html.EscapeString(lang) + `">`)
+			} else {
+				b.WriteString("<pre><code>")
+			}
+			b.WriteString(html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case mdHeading.MatchString(trimmed):
+			flushPara()
+			m := mdHeading.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+
+		case mdRule.MatchString(trimmed):
+			flushPara()
+			b.WriteString("<hr>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushPara()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				q := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quote = append(quote, strings.TrimPrefix(q, " "))
+			}
+			i--
+			b.WriteString("<blockquote>\n" + renderMarkdown(strings.Join(quote, "\n")) + "</blockquote>\n")
+
+		case mdUnordered.MatchString(trimmed), mdOrdered.MatchString(trimmed):
+			flushPara()
+			rx, tag := mdUnordered, "ul"
+			if !mdUnordered.MatchString(trimmed) {
+				rx, tag = mdOrdered, "ol"
+			}
+			b.WriteString("<" + tag + ">\n")
+			for ; i < len(lines) && rx.MatchString(strings.TrimSpace(lines[i])); i++ {
+				item := rx.FindStringSubmatch(strings.TrimSpace(lines[i]))[1]
+				b.WriteString("<li>" + renderInline(item) + "</li>\n")
+			}
+			i--
+			b.WriteString("</" + tag + ">\n")
+
+		default:
+			para = append(para, trimmed)
+		}
+	}
+	flushPara()
+
+	return b.String()
+}
+
+// renderInline renders the inline markdown of a block to html.
+// Code spans are escaped verbatim, and the remaining text is
+// escaped before links and emphasis are applied.
+func renderInline(text string) string {
+	var b strings.Builder
+
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		// Odd parts are within code spans, unless the span is not closed.
+		if i%2 == 1 && i < len(parts)-1 {
+			b.WriteString("<code>" + html.EscapeString(part) + "</code>")
+			continue
+		}
+		if i%2 == 1 {
+			b.WriteString("`")
+		}
+
+		// The urls of links are replaced with placeholders while emphasis
+		// is applied, so that a "_" or "*" in a url is not emphasized.
+		// A NUL in the text is replaced, so that it cannot forge a placeholder.
+		var hrefs []string
+		s := html.EscapeString(strings.ReplaceAll(part, "\x00", "\uFFFD"))
+		s = mdLink.ReplaceAllStringFunc(s, func(m string) string {
+			sub := mdLink.FindStringSubmatch(m)
+			href := html.UnescapeString(sub[2])
+			if !mdSafeScheme.MatchString(href) {
+				return sub[1]
+			}
+			hrefs = append(hrefs, html.EscapeString(href))
+			return "\x00" + strconv.Itoa(len(hrefs)-1) + "\x00" + sub[1] + "\x00/\x00"
+		})
+		s = mdStrong.ReplaceAllString(s, "<strong>$1$2</strong>")
+		s = mdEmphasis.ReplaceAllString(s, "<em>$1</em>")
+		s = mdPlaceholder.ReplaceAllStringFunc(s, func(m string) string {
+			if m == "\x00/\x00" {
+				return "</a>"
+			}
+			i, _ := strconv.Atoi(strings.Trim(m, "\x00"))
+			return `<a href="` + hrefs[i] + `">`
+		})
+		b.WriteString(s)
+	}
+
+	return b.String()
+}
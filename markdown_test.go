@@ -0,0 +1,49 @@
+package rio
+
+import (
+	"html/template"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Markdown
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"paragraph", "hello\nworld", "<p>hello\nworld</p>\n"},
+		{"heading", "## Title ##", "<h2>Title</h2>\n"},
+		{"emphasis", "**bold** and *em* and __strong__", "<p><strong>bold</strong> and <em>em</em> and <strong>strong</strong></p>\n"},
+		{"code-span", "run `<b>*x*</b>`", "<p>run <code>&lt;b&gt;*x*&lt;/b&gt;</code></p>\n"},
+		{"code-block", "```go\nx := 1 < 2\n```", "<pre><code class=\"language-go\">x := 1 &lt; 2</code></pre>\n"},
+		{"unordered", "- a\n- b", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		{"ordered", "1. a\n2. b", "<ol>\n<li>a</li>\n<li>b</li>\n</ol>\n"},
+		{"blockquote", "> quoted\n> text", "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>\n"},
+		{"rule", "a\n\n---\n\nb", "<p>a</p>\n<hr>\n<p>b</p>\n"},
+		{"link", "[site](https://example.com?a=1&b=2)", "<p><a href=\"https://example.com?a=1&amp;b=2\">site</a></p>\n"},
+		{"link-relative", "[home](/home)", "<p><a href=\"/home\">home</a></p>\n"},
+		{"link-unsafe", "[x](javascript:alert(1))", "<p>x)</p>\n"},
+		{"link-underscores", "[a_b](https://example.com/a_b_c) and _x_", "<p><a href=\"https://example.com/a_b_c\">a_b</a> and _x_</p>\n"},
+		{"link-emphasis", "*see [docs](https://example.com/*a*)*", "<p><em>see <a href=\"https://example.com/*a*\">docs</a></em></p>\n"},
+		{"link-nul", "\x000\x00 [a](/a)", "<p>\uFFFD0\uFFFD <a href=\"/a\">a</a></p>\n"},
+		{"raw-html", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert(t, Markdown(tt.src), template.HTML(tt.want))
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package rio
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -23,10 +27,24 @@ type logResponseWriter struct {
 }
 
 func (w *logResponseWriter) WriteHeader(status int) {
-	w.status = status
+	if !isInformational(status) {
+		w.status = status
+	}
 	w.ResponseWriter.WriteHeader(status)
 }
 
+// Unwrap returns the underlying ResponseWriter, so that
+// an http.ResponseController can reach it.
+func (w *logResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// isInformational checks if the status is a 1xx informational response,
+// like 103 Early Hints, which is followed by the final response status.
+func isInformational(status int) bool {
+	return status >= 100 && status < 200 && status != http.StatusSwitchingProtocols
+}
+
 // LogRequest is a middleware which logs the http request and response status.
 func LogRequest(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
@@ -37,11 +55,14 @@ func LogRequest(next http.Handler) http.Handler {
 
 		// Defer the logging call.
 		defer func(start time.Time) {
-			LogInfo(
+			logAttrs(
+				r.Context(),
+				0,
+				slog.LevelInfo,
 				"request",
 				slog.Int("status", ww.status),
 				slog.String("method", r.Method),
-				slog.String("url", r.URL.RequestURI()),
+				slog.String("url", redactUrl(r.URL)),
 				slog.Duration("time", time.Since(start)),
 			)
 		}(time.Now())
@@ -52,6 +73,112 @@ func LogRequest(next http.Handler) http.Handler {
 	return http.HandlerFunc(fn)
 }
 
+// ------------------------------------------------------------------
+//
+//
+// RequestId Middleware
+//
+//
+// ------------------------------------------------------------------
+
+// RequestIdHeader is the header which carries the request id.
+const RequestIdHeader = "X-Request-Id"
+
+// requestIdKey is the context key of the request ids.
+type requestIdKey struct{}
+
+// requestIds are the ids which correlate the logs of a request.
+type requestIds struct {
+	requestId string
+	traceId   string
+}
+
+// RequestId is a middleware which assigns an id to each request.
+//
+// The id is taken from the X-Request-Id request header, if it is valid,
+// or a random id is generated. The id is set on the response header,
+// and stored in the request context along with the trace id of the
+// W3C traceparent header, if any.
+//
+// Loggers constructed with NewLogger include the ids as the request_id
+// and trace_id attributes, so RequestId should come before LogRequest.
+//
+//	server.Use(RequestId, LogRequest)
+//
+// .
+func RequestId(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ids := requestIds{
+			requestId: r.Header.Get(RequestIdHeader),
+			traceId:   parseTraceparent(r.Header.Get("traceparent")),
+		}
+		if !validRequestId(ids.requestId) {
+			ids.requestId = newRequestId()
+		}
+
+		w.Header().Set(RequestIdHeader, ids.requestId)
+
+		ctx := context.WithValue(r.Context(), requestIdKey{}, ids)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
+
+// RequestIdFrom returns the request id stored in the context,
+// or an empty string if there is none.
+func RequestIdFrom(ctx context.Context) string {
+	ids, _ := ctx.Value(requestIdKey{}).(requestIds)
+	return ids.requestId
+}
+
+// TraceIdFrom returns the trace id stored in the context,
+// or an empty string if there is none.
+func TraceIdFrom(ctx context.Context) string {
+	ids, _ := ctx.Value(requestIdKey{}).(requestIds)
+	return ids.traceId
+}
+
+// newRequestId returns a random 32 character hex id.
+func newRequestId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestId checks if a client-provided request id is safe to use.
+func validRequestId(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, c := range id {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTraceparent returns the trace id of a W3C traceparent header,
+// like "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func parseTraceparent(header string) string {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 {
+		return ""
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil || parts[1] == strings.Repeat("0", 32) {
+		return ""
+	}
+	return parts[1]
+}
+
+// ------------------------------------------------------------------
+//
+//
+// SkipLogger Middleware
+//
+//
+// ------------------------------------------------------------------
+
 // SkipLogger is a middleware which logs the http request and response status
 // if the request url does not match the given path.
 func SkipLogger(excludePath string) func(http.Handler) http.Handler {
@@ -72,6 +199,97 @@ func SkipLogger(excludePath string) func(http.Handler) http.Handler {
 	}
 }
 
+// ------------------------------------------------------------------
+//
+//
+// CaptureResponse Middleware
+//
+//
+// ------------------------------------------------------------------
+
+// CapturedResponse is a copy of a response, for debugging.
+type CapturedResponse struct {
+	Status    int
+	Header    http.Header
+	Body      []byte
+	Truncated bool
+}
+
+// captureResponseWriter tees the response status, headers and body.
+type captureResponseWriter struct {
+	http.ResponseWriter
+	limit     int
+	status    int
+	body      []byte
+	truncated bool
+}
+
+func (w *captureResponseWriter) WriteHeader(status int) {
+	if !isInformational(status) {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *captureResponseWriter) Write(b []byte) (int, error) {
+	if room := w.limit - len(w.body); room > 0 {
+		w.body = append(w.body, b[:min(room, len(b))]...)
+		w.truncated = w.truncated || len(b) > room
+	} else if len(b) > 0 {
+		w.truncated = true
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter, so that
+// an http.ResponseController can reach it.
+func (w *captureResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// CaptureResponse is a middleware which logs the response status, headers
+// and body at debug level. At most limit bytes of the body are logged.
+//
+// It is meant for troubleshooting misbehaving endpoints, and
+// should not be enabled in production.
+func CaptureResponse(limit int) func(http.Handler) http.Handler {
+	return CaptureResponseTo(limit, func(r *http.Request, res CapturedResponse) {
+		LogDebug(
+			"response",
+			slog.String("method", r.Method),
+			slog.String("url", redactUrl(r.URL)),
+			slog.Int("status", res.Status),
+			slog.Any("header", redactHeader(res.Header)),
+			slog.String("body", string(res.Body)),
+			slog.Bool("truncated", res.Truncated),
+		)
+	})
+}
+
+// CaptureResponseTo is a middleware which passes a copy of the response
+// to the sink func. At most limit bytes of the body are captured.
+func CaptureResponseTo(limit int, sink func(*http.Request, CapturedResponse)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := &captureResponseWriter{
+				ResponseWriter: w,
+				limit:          limit,
+				status:         http.StatusOK,
+			}
+
+			next.ServeHTTP(ww, r)
+
+			sink(r, CapturedResponse{
+				Status:    ww.status,
+				Header:    w.Header().Clone(),
+				Body:      ww.body,
+				Truncated: ww.truncated,
+			})
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
 // ------------------------------------------------------------------
 //
 //
@@ -87,10 +305,11 @@ func RecoverPanic(next http.Handler) http.Handler {
 		// The deferred function will always run,
 		// even in the event of a panic.
 		defer func() {
-			if err := recover(); err != nil {
+			if rec := recover(); rec != nil {
 				w.Header().Set("Connection", "close")
-				LogError(err.(error))
-				Http500(w)
+				LogError(fmt.Errorf("%v", rec))
+				status := http.StatusInternalServerError
+				writeError(w, r, status, http.StatusText(status))
 			}
 		}()
 		next.ServeHTTP(w, r)
@@ -151,6 +370,17 @@ func CacheControlWithAge(age int) func(http.Handler) http.Handler {
 	}
 }
 
+// CacheControlWithPolicy is a middleware which sets the given caching policy.
+func CacheControlWithPolicy(p CachePolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			SetCacheHeaders(w, p)
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
 // ------------------------------------------------------------------
 //
 //
@@ -164,7 +394,8 @@ func CacheControlWithAge(age int) func(http.Handler) http.Handler {
 func NotFound(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/" {
-			Http404(w, http.StatusText(http.StatusNotFound))
+			status := http.StatusNotFound
+			writeError(w, r, status, http.StatusText(status))
 			return
 		}
 		next.ServeHTTP(w, r)
@@ -201,23 +432,74 @@ type HandlerFunc func(http.ResponseWriter, *http.Request) error
 // MakeHandler is a middleware which converts a rio.HandlerFunc to an http.Handler.
 // It centralizes the error handling with the custom AppError error type.
 func MakeHandler(next HandlerFunc) http.Handler {
-	fn := func(w http.ResponseWriter, r *http.Request) {
-		// Run the handler and check for errors.
-		if err := next(w, r); err != nil {
-			// If the error is an AppError, then write it to the ResponseWriter.
-			var appErr AppError
-			if errors.As(err, &appErr) {
-				if writeErr := appErr.WriteTo(w); writeErr != nil {
-					LogError(writeErr)
-					Http500(w)
-				}
-				return
+	return MakeHandlerWith(HandlerOpts{})(next)
+}
+
+// ErrorHandler is a function which handles an error returned by a rio.HandlerFunc.
+type ErrorHandler func(http.ResponseWriter, *http.Request, error)
+
+// HandlerOpts configures the handlers created by MakeHandlerWith.
+type HandlerOpts struct {
+	// ErrorHandler handles the errors returned by the handlers.
+	// Defaults to DefaultErrorHandler.
+	ErrorHandler ErrorHandler
+}
+
+// MakeHandlerWith returns a middleware which converts a rio.HandlerFunc to an http.Handler.
+// Errors returned by the handler are passed to the configured ErrorHandler.
+//
+//	makeHandler := MakeHandlerWith(HandlerOpts{
+//		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+//			if errors.Is(err, sql.ErrNoRows) {
+//				Http404(w, "not found")
+//				return
+//			}
+//			DefaultErrorHandler(w, r, err)
+//		},
+//	})
+//	mux.Handle("/users/{id}", makeHandler(userDetail))
+//
+// .
+func MakeHandlerWith(opts HandlerOpts) func(HandlerFunc) http.Handler {
+	errHandler := opts.ErrorHandler
+	if errHandler == nil {
+		errHandler = DefaultErrorHandler
+	}
+
+	return func(next HandlerFunc) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			// Run the handler and check for errors.
+			if err := next(w, r); err != nil {
+				errHandler(w, r, err)
 			}
-			// If the error is NOT an AppError, then log it
-			// and return a generic Http 500.
-			LogError(err)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// DefaultErrorHandler is the ErrorHandler used by MakeHandler.
+//
+// If the error is an AppError, then it is written to the ResponseWriter.
+// Otherwise, the error is logged and a generic Http 500 is written.
+// Http errors are rendered with the ErrorPages, if they are configured.
+func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	// If the error is an AppError, then write it to the ResponseWriter.
+	// Http AppErrors are written with the error pages, if they are configured.
+	var appErr AppError
+	if errors.As(err, &appErr) {
+		if !appErr.IsJson {
+			writeError(w, r, appErr.Status, appErr.Message)
+			return
+		}
+		if writeErr := appErr.WriteTo(w); writeErr != nil {
+			LogErrorCtx(r.Context(), writeErr)
 			Http500(w)
 		}
+		return
 	}
-	return http.HandlerFunc(fn)
+	// If the error is NOT an AppError, then log it
+	// and return a generic Http 500.
+	LogErrorCtx(r.Context(), err)
+	status := http.StatusInternalServerError
+	writeError(w, r, status, http.StatusText(status))
 }
@@ -0,0 +1,201 @@
+package rio
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// MakeHandler
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestMakeHandler(t *testing.T) {
+	t.Run("AppError", func(t *testing.T) {
+		h := MakeHandler(func(w http.ResponseWriter, r *http.Request) error {
+			return HttpError("nope", http.StatusConflict)
+		})
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		assert(t, w.Code, http.StatusConflict)
+		assert(t, w.Body.String(), "nope\n")
+	})
+
+	t.Run("MakeHandlerWith", func(t *testing.T) {
+		errDomain := errors.New("domain error")
+		var gotErr error
+
+		makeHandler := MakeHandlerWith(HandlerOpts{
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				gotErr = err
+				HttpStatus(w, http.StatusTeapot, "custom")
+			},
+		})
+		h := makeHandler(func(w http.ResponseWriter, r *http.Request) error {
+			return errDomain
+		})
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		assert(t, gotErr, errDomain)
+		assert(t, w.Code, http.StatusTeapot)
+		assert(t, w.Body.String(), "custom\n")
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// CaptureResponse
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestCaptureResponse(t *testing.T) {
+	var got CapturedResponse
+	sink := func(r *http.Request, res CapturedResponse) {
+		got = res
+	}
+
+	h := CaptureResponseTo(5, sink)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Test", "yes")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("hello "))
+		w.Write([]byte("world"))
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	assert(t, w.Body.String(), "hello world")
+	assert(t, got.Status, http.StatusAccepted)
+	assert(t, got.Header.Get("X-Test"), "yes")
+	assert(t, string(got.Body), "hello")
+	assert(t, got.Truncated, true)
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// RequestId
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestRequestId(t *testing.T) {
+	t.Run("generated", func(t *testing.T) {
+		var ctxId string
+		h := RequestId(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctxId = RequestIdFrom(r.Context())
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		assert(t, len(ctxId), 32)
+		assert(t, w.Header().Get(RequestIdHeader), ctxId)
+	})
+
+	t.Run("from-headers", func(t *testing.T) {
+		var ctxId, traceId string
+		h := RequestId(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctxId = RequestIdFrom(r.Context())
+			traceId = TraceIdFrom(r.Context())
+		}))
+
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set(RequestIdHeader, "abc-123")
+		r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+
+		assert(t, ctxId, "abc-123")
+		assert(t, traceId, "4bf92f3577b34da6a3ce929d0e0e4736")
+	})
+
+	t.Run("invalid-header", func(t *testing.T) {
+		var ctxId string
+		h := RequestId(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctxId = RequestIdFrom(r.Context())
+		}))
+
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set(RequestIdHeader, "bad id\n")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+
+		assert(t, len(ctxId), 32)
+	})
+
+	t.Run("LogRequest", func(t *testing.T) {
+		defer Logger(defaultLogger)
+
+		var buf bytes.Buffer
+		Logger(NewLogger(&buf))
+
+		h := RequestId(LogRequest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			LoggerFrom(r.Context()).Info("inside")
+		})))
+
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set(RequestIdHeader, "req-1")
+		h.ServeHTTP(httptest.NewRecorder(), r)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert(t, len(lines), 2)
+		assert(t, strings.HasSuffix(lines[0], "request_id=req-1"), true)
+		assert(t, strings.HasSuffix(lines[1], "request_id=req-1"), true)
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// RecoverPanic
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestRecoverPanic(t *testing.T) {
+	defer Logger(defaultLogger)
+
+	for _, value := range []any{"boom", errors.New("boom"), 42} {
+		var buf bytes.Buffer
+		Logger(NewLogger(&buf))
+
+		h := RecoverPanic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(value)
+		}))
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		assert(t, w.Code, http.StatusInternalServerError)
+		assert(t, w.Header().Get("Connection"), "close")
+		assert(t, strings.Contains(buf.String(), "level=ERROR"), true)
+	}
+}
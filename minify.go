@@ -0,0 +1,113 @@
+package rio
+
+import (
+	"bytes"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// HTML Minification
+//
+//
+// ------------------------------------------------------------------
+
+// rawTags are the elements whose content is copied verbatim by the minifier.
+var rawTags = []string{"pre", "textarea", "script", "style"}
+
+// minifyHtml writes a conservatively minified copy of the html to dst.
+//
+// Comments are removed, except for conditional comments, and runs of
+// whitespace are collapsed into a single space. The content of pre,
+// textarea, script and style elements, and quoted attribute values,
+// are left untouched.
+func minifyHtml(dst *bytes.Buffer, src []byte) {
+	inTag := false
+	var quote byte
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+
+		switch {
+		// Copy quoted attribute values verbatim.
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			dst.WriteByte(c)
+
+		case inTag && (c == '"' || c == '\''):
+			quote = c
+			dst.WriteByte(c)
+
+		case inTag && c == '>':
+			inTag = false
+			dst.WriteByte(c)
+
+		case !inTag && bytes.HasPrefix(src[i:], []byte("<!--")):
+			end := bytes.Index(src[i+4:], []byte("-->"))
+			if end < 0 {
+				dst.Write(src[i:])
+				return
+			}
+			end += i + 4 + len("-->")
+			if bytes.HasPrefix(src[i+4:], []byte("[if")) {
+				dst.Write(src[i:end])
+			}
+			i = end - 1
+
+		case !inTag && c == '<':
+			if tag, ok := rawTagAt(src[i:]); ok {
+				end := rawTagEnd(src, i, tag)
+				dst.Write(src[i:end])
+				i = end - 1
+				continue
+			}
+			inTag = true
+			dst.WriteByte(c)
+
+		case isSpace(c):
+			for i+1 < len(src) && isSpace(src[i+1]) {
+				i++
+			}
+			dst.WriteByte(' ')
+
+		default:
+			dst.WriteByte(c)
+		}
+	}
+}
+
+// rawTagAt returns the raw element which opens at the start of b, if any.
+func rawTagAt(b []byte) (string, bool) {
+	for _, tag := range rawTags {
+		n := 1 + len(tag)
+		if len(b) > n && bytes.EqualFold(b[1:n], []byte(tag)) && (b[n] == '>' || b[n] == '/' || isSpace(b[n])) {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+// rawTagEnd returns the index after the closing tag of the raw element
+// which opens at src[start], or the length of src if it is not closed.
+func rawTagEnd(src []byte, start int, tag string) int {
+	closing := []byte("</" + tag)
+
+	for i := start; i+len(closing) <= len(src); i++ {
+		if !bytes.EqualFold(src[i:i+len(closing)], closing) {
+			continue
+		}
+		end := bytes.IndexByte(src[i:], '>')
+		if end < 0 {
+			return len(src)
+		}
+		return i + end + 1
+	}
+	return len(src)
+}
+
+// isSpace checks if the byte is html whitespace.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,43 @@
+package rio
+
+import (
+	"bytes"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// HTML Minification
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestMinifyHtml(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"whitespace", "<ul>\n    <li>a</li>\n\t<li>b</li>\n</ul>\n", "<ul> <li>a</li> <li>b</li> </ul> "},
+		{"comments", "<p>a<!-- note -->b</p>", "<p>ab</p>"},
+		{"conditional-comment", "<!--[if IE]>x<![endif]-->", "<!--[if IE]>x<![endif]-->"},
+		{"pre", "<pre>\n  a\n    b\n</pre>  <p>  c  </p>", "<pre>\n  a\n    b\n</pre> <p> c </p>"},
+		{"pre-invalid-utf8", "<pre>\xff\xff\xff\xff</PRE>  <p>", "<pre>\xff\xff\xff\xff</PRE> <p>"},
+		{"script", "<SCRIPT>\n if (a  <  b) {}\n</script>", "<SCRIPT>\n if (a  <  b) {}\n</script>"},
+		{"attributes", "<a  title=\"a  b\"\n  href='/'>x</a>", "<a title=\"a  b\" href='/'>x</a>"},
+		{"prefix-tag", "<prefix>\n a </prefix>", "<prefix> a </prefix>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			minifyHtml(&buf, []byte(tt.src))
+			assert(t, buf.String(), tt.want)
+		})
+	}
+}
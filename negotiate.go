@@ -0,0 +1,141 @@
+package rio
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Content Negotiation
+//
+//
+// ------------------------------------------------------------------
+
+// Offers are the response formats a handler can serve.
+// Formats with a nil func are not offered.
+type Offers struct {
+	HTML func()
+	JSON func()
+	Text func()
+}
+
+// Negotiate calls the offer which best matches the request's Accept header.
+//
+// If the request has no Accept header, then the first available offer is
+// called, in the order of HTML, JSON and Text. If no offer is acceptable,
+// then a 406 Not Acceptable is written.
+//
+//	Negotiate(w, r, Offers{
+//		HTML: func() { Render(w, "user.html", http.StatusOK, user) },
+//		JSON: func() { Json200(w, user) },
+//	})
+//
+// .
+func Negotiate(w http.ResponseWriter, r *http.Request, offers Offers) {
+	w.Header().Add("Vary", "Accept")
+
+	candidates := []struct {
+		mediaType string
+		fn        func()
+	}{
+		{"text/html", offers.HTML},
+		{"application/json", offers.JSON},
+		{"text/plain", offers.Text},
+	}
+
+	ranges := parseAccept(r.Header.Get("Accept"))
+
+	var best func()
+	var bestQ float64
+
+	for _, c := range candidates {
+		if c.fn == nil {
+			continue
+		}
+		if q := acceptQuality(ranges, c.mediaType); q > bestQ {
+			best, bestQ = c.fn, q
+		}
+	}
+
+	if best == nil {
+		status := http.StatusNotAcceptable
+		HttpStatus(w, status, http.StatusText(status))
+		return
+	}
+	best()
+}
+
+// Accepts returns true if the request's Accept header allows the given media type.
+//
+//	if Accepts(r, "application/json") {
+//		return Json200(w, data)
+//	}
+//
+// .
+func Accepts(r *http.Request, mediaType string) bool {
+	return acceptQuality(parseAccept(r.Header.Get("Accept")), mediaType) > 0
+}
+
+// acceptRange is a media range of the Accept header, with its quality value.
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept parses the Accept header into a slice of media ranges.
+// An empty header is treated as "*/*".
+func parseAccept(header string) []acceptRange {
+	if strings.TrimSpace(header) == "" {
+		return []acceptRange{{mediaType: "*/*", q: 1}}
+	}
+
+	var ranges []acceptRange
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		ar := acceptRange{
+			mediaType: strings.ToLower(strings.TrimSpace(mediaType)),
+			q:         1,
+		}
+
+		for _, param := range strings.Split(params, ";") {
+			key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && key == "q" {
+				if q, err := strconv.ParseFloat(val, 64); err == nil {
+					ar.q = q
+				}
+			}
+		}
+
+		if ar.mediaType != "" {
+			ranges = append(ranges, ar)
+		}
+	}
+	return ranges
+}
+
+// acceptQuality returns the quality of the media type, from the most
+// specific media range which matches it. Returns 0 if there is no match.
+func acceptQuality(ranges []acceptRange, mediaType string) float64 {
+	mediaType = strings.ToLower(mediaType)
+	typ, _, _ := strings.Cut(mediaType, "/")
+
+	q, specificity := 0.0, 0
+	for _, ar := range ranges {
+		s := 0
+		switch ar.mediaType {
+		case mediaType:
+			s = 3
+		case typ + "/*":
+			s = 2
+		case "*/*":
+			s = 1
+		}
+		if s > specificity {
+			q, specificity = ar.q, s
+		}
+	}
+	return q
+}
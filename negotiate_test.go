@@ -0,0 +1,75 @@
+package rio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Content Negotiation
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestNegotiate(t *testing.T) {
+	negotiate := func(accept string, offers func(*string) Offers) (string, int) {
+		r := httptest.NewRequest("GET", "/", nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		var got string
+		Negotiate(w, r, offers(&got))
+		return got, w.Code
+	}
+
+	all := func(got *string) Offers {
+		return Offers{
+			HTML: func() { *got = "html" },
+			JSON: func() { *got = "json" },
+			Text: func() { *got = "text" },
+		}
+	}
+
+	jsonOnly := func(got *string) Offers {
+		return Offers{JSON: func() { *got = "json" }}
+	}
+
+	tests := []struct {
+		accept string
+		offers func(*string) Offers
+		want   string
+		status int
+	}{
+		{"", all, "html", http.StatusOK},
+		{"text/html,application/xhtml+xml,*/*;q=0.8", all, "html", http.StatusOK},
+		{"application/json", all, "json", http.StatusOK},
+		{"text/*;q=0.5, application/json;q=0.9", all, "json", http.StatusOK},
+		{"text/plain, text/*;q=0.1", all, "text", http.StatusOK},
+		{"*/*", jsonOnly, "json", http.StatusOK},
+		{"text/html", jsonOnly, "", http.StatusNotAcceptable},
+		{"application/json;q=0", jsonOnly, "", http.StatusNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		got, status := negotiate(tt.accept, tt.offers)
+		assert(t, got, tt.want)
+		assert(t, status, tt.status)
+	}
+}
+
+func TestAccepts(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	assert(t, Accepts(r, "application/json"), true)
+
+	r.Header.Set("Accept", "text/html, application/*;q=0.2")
+	assert(t, Accepts(r, "application/json"), true)
+	assert(t, Accepts(r, "text/plain"), false)
+}
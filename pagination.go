@@ -0,0 +1,157 @@
+package rio
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Pagination
+//
+//
+// ------------------------------------------------------------------
+
+// Pagination describes the requested page of a list of items.
+//
+// It is parsed from the "page", "per_page" and "cursor" query params
+// of a request, and can be passed directly to templates.
+//
+//	p := Paginate(r, 20, 100)
+//	users, total := store.ListUsers(ctx, p.Offset(), p.Limit())
+//	p = p.WithTotal(total)
+//	p.SetLinkHeader(w)
+//
+// .
+type Pagination struct {
+	Page    int
+	PerPage int
+	Total   int
+	Cursor  string
+
+	url *url.URL
+}
+
+// Paginate parses the pagination query params of the request.
+//
+// The page defaults to 1. The per page defaults to defaultPerPage,
+// and is clamped between 1 and maxPerPage.
+func Paginate(r *http.Request, defaultPerPage, maxPerPage int) Pagination {
+	query := r.URL.Query()
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	perPage = max(1, min(perPage, maxPerPage))
+
+	u := *r.URL
+	return Pagination{
+		Page:    page,
+		PerPage: perPage,
+		Cursor:  query.Get("cursor"),
+		url:     &u,
+	}
+}
+
+// WithTotal returns a copy of the Pagination with the total number of items.
+func (p Pagination) WithTotal(total int) Pagination {
+	p.Total = total
+	return p
+}
+
+// Offset returns the number of items to skip.
+//
+// It saturates at math.MaxInt, so a huge page from the query
+// cannot overflow into a negative offset.
+func (p Pagination) Offset() int {
+	if p.Page <= 1 || p.PerPage <= 0 {
+		return 0
+	}
+	if p.Page-1 > math.MaxInt/p.PerPage {
+		return math.MaxInt
+	}
+	return (p.Page - 1) * p.PerPage
+}
+
+// Limit returns the number of items in a page.
+func (p Pagination) Limit() int {
+	return p.PerPage
+}
+
+// TotalPages returns the number of pages. There is always at least 1 page.
+func (p Pagination) TotalPages() int {
+	if p.Total <= 0 || p.PerPage <= 0 {
+		return 1
+	}
+	return (p.Total + p.PerPage - 1) / p.PerPage
+}
+
+// HasPrev returns true if there is a page before the current page.
+func (p Pagination) HasPrev() bool {
+	return p.Page > 1
+}
+
+// HasNext returns true if there is a page after the current page.
+func (p Pagination) HasNext() bool {
+	return p.Page < p.TotalPages()
+}
+
+// PrevPage returns the previous page number.
+func (p Pagination) PrevPage() int {
+	return max(1, p.Page-1)
+}
+
+// NextPage returns the next page number.
+func (p Pagination) NextPage() int {
+	return min(p.TotalPages(), p.Page+1)
+}
+
+// Pages returns all page numbers, for rendering page links.
+func (p Pagination) Pages() []int {
+	pages := make([]int, p.TotalPages())
+	for i := range pages {
+		pages[i] = i + 1
+	}
+	return pages
+}
+
+// PageUrl returns the request url with the page query param set to the given page.
+func (p Pagination) PageUrl(page int) string {
+	if p.url == nil {
+		return fmt.Sprintf("?page=%d", page)
+	}
+
+	u := *p.url
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(p.PerPage))
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+// SetLinkHeader sets the Link header with the first, prev, next and last page urls.
+func (p Pagination) SetLinkHeader(w http.ResponseWriter) {
+	links := []string{
+		fmt.Sprintf(`<%s>; rel="first"`, p.PageUrl(1)),
+	}
+	if p.HasPrev() {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, p.PageUrl(p.PrevPage())))
+	}
+	if p.HasNext() {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, p.PageUrl(p.NextPage())))
+	}
+	links = append(links, fmt.Sprintf(`<%s>; rel="last"`, p.PageUrl(p.TotalPages())))
+
+	w.Header().Set("Link", strings.Join(links, ", "))
+}
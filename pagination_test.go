@@ -0,0 +1,77 @@
+package rio
+
+import (
+	"math"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Pagination
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestPagination(t *testing.T) {
+	t.Run("Paginate-defaults", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/users?page=bad&per_page=-1", nil)
+		p := Paginate(r, 20, 100)
+
+		assert(t, p.Page, 1)
+		assert(t, p.PerPage, 20)
+		assert(t, p.Offset(), 0)
+	})
+
+	t.Run("Paginate-bounds", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/users?page=3&per_page=500&cursor=abc", nil)
+		p := Paginate(r, 20, 100)
+
+		assert(t, p.Page, 3)
+		assert(t, p.PerPage, 100)
+		assert(t, p.Cursor, "abc")
+		assert(t, p.Offset(), 200)
+		assert(t, p.Limit(), 100)
+	})
+
+	t.Run("Offset-overflow", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/users?page="+strconv.Itoa(math.MaxInt)+"&per_page=50", nil)
+		p := Paginate(r, 20, 100)
+
+		assert(t, p.Offset(), math.MaxInt)
+		assert(t, Pagination{Page: 0, PerPage: 20}.Offset(), 0)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/users?page=2&per_page=10", nil)
+		p := Paginate(r, 20, 100).WithTotal(25)
+
+		assert(t, p.TotalPages(), 3)
+		assert(t, p.HasPrev(), true)
+		assert(t, p.HasNext(), true)
+		assert(t, p.PrevPage(), 1)
+		assert(t, p.NextPage(), 3)
+		assert(t, len(p.Pages()), 3)
+		assert(t, Pagination{}.TotalPages(), 1)
+	})
+
+	t.Run("SetLinkHeader", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/users?q=x&page=2&per_page=10", nil)
+		p := Paginate(r, 20, 100).WithTotal(25)
+
+		w := httptest.NewRecorder()
+		p.SetLinkHeader(w)
+
+		want := `</users?page=1&per_page=10&q=x>; rel="first", ` +
+			`</users?page=1&per_page=10&q=x>; rel="prev", ` +
+			`</users?page=3&per_page=10&q=x>; rel="next", ` +
+			`</users?page=3&per_page=10&q=x>; rel="last"`
+		assert(t, w.Header().Get("Link"), want)
+	})
+}
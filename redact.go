@@ -0,0 +1,147 @@
+package rio
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Log Redaction
+//
+//
+// ------------------------------------------------------------------
+
+// redacted replaces the values of sensitive fields.
+const redacted = "[REDACTED]"
+
+var (
+	redactMu   sync.RWMutex
+	redactKeys = []string{"password", "passwd", "secret", "token", "authorization", "cookie", "api_key", "apikey"}
+)
+
+// RedactKeys adds key patterns to redact from logs. A key is redacted
+// if it contains any of the patterns, ignoring case.
+//
+// By default, keys containing password, passwd, secret, token,
+// authorization, cookie, api_key and apikey are redacted. Redaction
+// applies to log attributes, request query params and logged headers.
+//
+//	RedactKeys("ssn", "card_number")
+//
+// .
+func RedactKeys(patterns ...string) {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+
+	for _, p := range patterns {
+		redactKeys = append(redactKeys, strings.ToLower(p))
+	}
+}
+
+// isSensitive checks if the key matches any of the redact patterns.
+func isSensitive(key string) bool {
+	redactMu.RLock()
+	defer redactMu.RUnlock()
+
+	key = strings.ToLower(key)
+	for _, p := range redactKeys {
+		if strings.Contains(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactUrl returns the request uri of the url, with sensitive query params redacted.
+func redactUrl(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.RequestURI()
+	}
+
+	query := u.Query()
+	changed := false
+	for key := range query {
+		if isSensitive(key) {
+			query[key] = []string{redacted}
+			changed = true
+		}
+	}
+	if !changed {
+		return u.RequestURI()
+	}
+
+	c := *u
+	c.RawQuery = query.Encode()
+	return c.RequestURI()
+}
+
+// redactHeader returns a copy of the header, with sensitive values redacted.
+func redactHeader(h http.Header) http.Header {
+	c := h.Clone()
+	for key := range c {
+		if isSensitive(key) {
+			c[key] = []string{redacted}
+		}
+	}
+	return c
+}
+
+// redactAttr redacts the attribute if its key is sensitive,
+// or redacts the attributes of a group.
+func redactAttr(a slog.Attr) slog.Attr {
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i := range group {
+			attrs[i] = redactAttr(group[i])
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	}
+	return a
+}
+
+// RedactHandler returns a slog.Handler which redacts the values of
+// attributes with sensitive keys, before passing records to the given
+// handler. Loggers constructed with NewLogger already redact.
+func RedactHandler(h slog.Handler) slog.Handler {
+	return &redactHandler{h}
+}
+
+// redactHandler is a slog.Handler which redacts sensitive attributes.
+type redactHandler struct {
+	slog.Handler
+}
+
+// Handle redacts the record's attributes, and passes it to the wrapped handler.
+func (h *redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	clean := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		clean.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, clean)
+}
+
+// WithAttrs returns a new redactHandler, with the attributes redacted.
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clean := make([]slog.Attr, len(attrs))
+	for i := range attrs {
+		clean[i] = redactAttr(attrs[i])
+	}
+	return &redactHandler{h.Handler.WithAttrs(clean)}
+}
+
+// WithGroup returns a new redactHandler, wrapping the handler with the group.
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{h.Handler.WithGroup(name)}
+}
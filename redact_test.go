@@ -0,0 +1,70 @@
+package rio
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Log Redaction
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestRedaction(t *testing.T) {
+	t.Run("attrs", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := NewLogger(&buf).With(slog.String("api_key", "k1"))
+
+		logger.Info("login",
+			slog.String("user", "alice"),
+			slog.String("Password", "hunter2"),
+			slog.Group("auth", slog.String("access_token", "t1")),
+		)
+
+		assert(t, strings.HasSuffix(strings.TrimSpace(buf.String()),
+			`msg=login api_key=[REDACTED] user=alice Password=[REDACTED] auth.access_token=[REDACTED]`), true)
+	})
+
+	t.Run("RedactKeys", func(t *testing.T) {
+		defer func(keys []string) { redactKeys = keys }(redactKeys)
+
+		assert(t, isSensitive("user_ssn"), false)
+		RedactKeys("SSN")
+		assert(t, isSensitive("user_ssn"), true)
+	})
+
+	t.Run("LogRequest", func(t *testing.T) {
+		defer Logger(defaultLogger)
+
+		var buf bytes.Buffer
+		Logger(NewLogger(&buf))
+
+		h := LogRequest(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/reset?token=abc&page=2", nil))
+
+		assert(t, strings.Contains(buf.String(), `url="/reset?page=2&token=%5BREDACTED%5D"`), true)
+	})
+
+	t.Run("redactHeader", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("Authorization", "Bearer abc")
+		h.Set("Content-Type", "text/plain")
+
+		c := redactHeader(h)
+
+		assert(t, c.Get("Authorization"), redacted)
+		assert(t, c.Get("Content-Type"), "text/plain")
+		assert(t, h.Get("Authorization"), "Bearer abc")
+	})
+}
@@ -1,8 +1,18 @@
 package rio
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
 	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/tunedmystic/rio/forms"
 )
 
 // ------------------------------------------------------------------
@@ -14,32 +24,42 @@ import (
 // ------------------------------------------------------------------
 
 func Http200(w http.ResponseWriter, msg string) {
-	http.Error(w, msg, http.StatusOK)
+	HttpStatus(w, http.StatusOK, msg)
 }
 
 func Http301(w http.ResponseWriter, msg string) {
-	http.Error(w, msg, http.StatusMovedPermanently)
+	HttpStatus(w, http.StatusMovedPermanently, msg)
 }
 
 func Http400(w http.ResponseWriter, msg string) {
-	http.Error(w, msg, http.StatusBadRequest)
+	HttpStatus(w, http.StatusBadRequest, msg)
 }
 
 func Http401(w http.ResponseWriter, msg string) {
-	http.Error(w, msg, http.StatusUnauthorized)
+	HttpStatus(w, http.StatusUnauthorized, msg)
 }
 
 func Http403(w http.ResponseWriter, msg string) {
-	http.Error(w, msg, http.StatusForbidden)
+	HttpStatus(w, http.StatusForbidden, msg)
 }
 
 func Http404(w http.ResponseWriter, msg string) {
-	http.Error(w, msg, http.StatusNotFound)
+	HttpStatus(w, http.StatusNotFound, msg)
 }
 
 func Http500(w http.ResponseWriter) {
 	status := http.StatusInternalServerError
-	http.Error(w, http.StatusText(status), status)
+	HttpStatus(w, status, http.StatusText(status))
+}
+
+// HttpStatus writes a plain text message with the given status.
+// It is useful for statuses which do not have a dedicated helper.
+//
+//	HttpStatus(w, http.StatusConflict, "already exists")
+//
+// .
+func HttpStatus(w http.ResponseWriter, status int, msg string) {
+	http.Error(w, msg, status)
 }
 
 // ------------------------------------------------------------------
@@ -51,39 +71,49 @@ func Http500(w http.ResponseWriter) {
 // ------------------------------------------------------------------
 
 func Json200(w http.ResponseWriter, data any) error {
-	return writeJson(w, data, http.StatusOK)
+	return JsonStatus(w, http.StatusOK, data)
 }
 
 func Json201(w http.ResponseWriter, data any) error {
-	return writeJson(w, data, http.StatusCreated)
+	return JsonStatus(w, http.StatusCreated, data)
 }
 
 func Json204(w http.ResponseWriter, data any) error {
-	return writeJson(w, data, http.StatusNoContent)
+	return JsonStatus(w, http.StatusNoContent, data)
 }
 
 func Json301(w http.ResponseWriter, data any) error {
-	return writeJson(w, data, http.StatusMovedPermanently)
+	return JsonStatus(w, http.StatusMovedPermanently, data)
 }
 
 func Json400(w http.ResponseWriter, data any) error {
-	return writeJson(w, data, http.StatusBadRequest)
+	return JsonStatus(w, http.StatusBadRequest, data)
 }
 
 func Json401(w http.ResponseWriter, data any) error {
-	return writeJson(w, data, http.StatusUnauthorized)
+	return JsonStatus(w, http.StatusUnauthorized, data)
 }
 
 func Json403(w http.ResponseWriter, data any) error {
-	return writeJson(w, data, http.StatusForbidden)
+	return JsonStatus(w, http.StatusForbidden, data)
 }
 
 func Json404(w http.ResponseWriter, data any) error {
-	return writeJson(w, data, http.StatusNotFound)
+	return JsonStatus(w, http.StatusNotFound, data)
 }
 
 func Json500(w http.ResponseWriter) error {
-	return writeJson(w, nil, http.StatusInternalServerError)
+	return JsonStatus(w, http.StatusInternalServerError, nil)
+}
+
+// JsonStatus writes the data as json with the given status.
+// It is useful for statuses which do not have a dedicated helper.
+//
+//	JsonStatus(w, http.StatusUnprocessableEntity, errs)
+//
+// .
+func JsonStatus(w http.ResponseWriter, status int, data any) error {
+	return writeJson(w, data, status)
 }
 
 // defaultJsonMessage represents a simple json message.
@@ -97,26 +127,329 @@ type defaultJsonMessage struct {
 //   - If data is a string, then it is also wrapped in a default message struct.
 //   - If data is a struct, then it is simply written to the ResponseWriter.
 func writeJson(w http.ResponseWriter, data any, status int) error {
+	js, err := json.Marshal(jsonPayload(data, status))
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(js)
+
+	return nil
+}
+
+// jsonPayload wraps nil and string data in a default message struct.
+func jsonPayload(data any, status int) any {
 	if data == nil {
-		data = defaultJsonMessage{
+		return defaultJsonMessage{
 			Message: http.StatusText(status),
 		}
 	}
 
 	if dataStr, ok := data.(string); ok {
-		data = defaultJsonMessage{
+		return defaultJsonMessage{
 			Message: dataStr,
 		}
 	}
 
-	js, err := json.Marshal(data)
-	if err != nil {
+	return data
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Json Envelope Responses
+//
+//
+// ------------------------------------------------------------------
+
+// jsonEnvelope is the standard json response body.
+type jsonEnvelope struct {
+	Data   any             `json:"data,omitempty"`
+	Meta   any             `json:"meta,omitempty"`
+	Errors []envelopeError `json:"errors,omitempty"`
+}
+
+// envelopeError is an error within the standard json response body.
+type envelopeError struct {
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"`
+	Status  int    `json:"status,omitempty"`
+}
+
+// JsonData writes the data and meta in a standard json envelope.
+// The meta is omitted if it is nil.
+//
+//	JsonData(w, http.StatusOK, users, map[string]int{"total": total})
+//	// {"data":[...],"meta":{"total":42}}
+//
+// .
+func JsonData(w http.ResponseWriter, status int, data, meta any) error {
+	return writeJson(w, jsonEnvelope{Data: data, Meta: meta}, status)
+}
+
+// JsonErrors writes the errors in a standard json envelope.
+//
+// A forms.FieldError includes the field name, and an AppError includes its status.
+//
+//	JsonErrors(w, http.StatusUnprocessableEntity, form.Errors())
+//	// {"errors":[{"message":"cannot be blank","field":"email"}]}
+//
+// .
+func JsonErrors(w http.ResponseWriter, status int, errs []error) error {
+	env := jsonEnvelope{
+		Errors: make([]envelopeError, 0, len(errs)),
+	}
+
+	for _, err := range errs {
+		var fieldErr forms.FieldError
+		var appErr AppError
+
+		switch {
+		case errors.As(err, &fieldErr):
+			env.Errors = append(env.Errors, envelopeError{Message: fieldErr.Err.Error(), Field: fieldErr.Name})
+		case errors.As(err, &appErr):
+			env.Errors = append(env.Errors, envelopeError{Message: appErr.Message, Status: appErr.Status})
+		default:
+			env.Errors = append(env.Errors, envelopeError{Message: err.Error()})
+		}
+	}
+
+	return writeJson(w, env, status)
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Json Streaming Responses
+//
+//
+// ------------------------------------------------------------------
+
+// JsonOpt is a function to configure a streaming json response.
+type JsonOpt func(*jsonConfig)
+
+// jsonConfig holds the configuration of a streaming json response.
+type jsonConfig struct {
+	buffered bool
+}
+
+// WithBuffer encodes the entire payload into a buffer before
+// anything is written to the ResponseWriter.
+//
+// This uses more memory, but ensures that an encoding error
+// can still be reported with the correct status.
+func WithBuffer() JsonOpt {
+	return func(c *jsonConfig) {
+		c.buffered = true
+	}
+}
+
+// JsonStream writes the data as json with the given status.
+//
+// The data is encoded directly to the ResponseWriter with a json.Encoder,
+// so large payloads are not held in memory. Because the status is written
+// first, an encoding error cannot change the status of the response,
+// unless the WithBuffer option is provided.
+//
+//	JsonStream(w, http.StatusOK, report)
+//
+// .
+func JsonStream(w http.ResponseWriter, status int, data any, opts ...JsonOpt) error {
+	return streamJson(w, status, opts, func(out io.Writer) error {
+		return json.NewEncoder(out).Encode(jsonPayload(data, status))
+	})
+}
+
+// JsonSeq writes the values of the sequence as a json array with the given status.
+//
+// Each value is encoded and written as it is produced, so large lists
+// can be written without collecting them into a slice first.
+//
+//	JsonSeq(w, http.StatusOK, store.AllUsers(ctx))
+//
+// .
+func JsonSeq[T any](w http.ResponseWriter, status int, seq iter.Seq[T], opts ...JsonOpt) error {
+	return streamJson(w, status, opts, func(out io.Writer) error {
+		if _, err := io.WriteString(out, "["); err != nil {
+			return err
+		}
+
+		first := true
+		for val := range seq {
+			js, err := json.Marshal(val)
+			if err != nil {
+				return err
+			}
+			if !first {
+				js = append([]byte(","), js...)
+			}
+			first = false
+
+			if _, err := out.Write(js); err != nil {
+				return err
+			}
+		}
+
+		_, err := io.WriteString(out, "]\n")
 		return err
+	})
+}
+
+// JsonChan writes the values received from the channel as a json array
+// with the given status. The array is closed when the channel is closed.
+//
+// If the encoding or a write fails, then the error is returned, and the
+// channel is drained in the background, so that the producer does not block
+// on a send. The producer must still close the channel when it is done.
+func JsonChan[T any](w http.ResponseWriter, status int, ch <-chan T, opts ...JsonOpt) error {
+	seq := func(yield func(T) bool) {
+		for val := range ch {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+
+	err := JsonSeq(w, status, seq, opts...)
+	if err != nil {
+		go func() {
+			for range ch {
+			}
+		}()
+	}
+	return err
+}
+
+// streamJson is a helper function which prepares the response and
+// runs the encode func against the ResponseWriter, or against a buffer
+// if the response is buffered.
+func streamJson(w http.ResponseWriter, status int, opts []JsonOpt, encode func(io.Writer) error) error {
+	var c jsonConfig
+	for i := range opts {
+		opts[i](&c)
+	}
+
+	if c.buffered {
+		buf := getBuffer()
+		defer putBuffer(buf)
+
+		if err := encode(buf); err != nil {
+			return err
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		buf.WriteTo(w)
+		return nil
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	w.Write(js)
+	return encode(w)
+}
 
-	return nil
+// ------------------------------------------------------------------
+//
+//
+// Csv Responses
+//
+//
+// ------------------------------------------------------------------
+
+// Csv writes the headers and rows as a csv file download with the given status.
+//
+// The rows are written as they are produced, so large exports are not
+// held in memory. If the headers are nil, then no header row is written.
+//
+//	Csv(w, http.StatusOK, []string{"id", "email"}, func(yield func([]string) bool) {
+//		for _, u := range users {
+//			if !yield([]string{u.ID, u.Email}) {
+//				return
+//			}
+//		}
+//	})
+//
+// .
+func Csv(w http.ResponseWriter, status int, headers []string, rows iter.Seq[[]string]) error {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	if w.Header().Get("Content-Disposition") == "" {
+		w.Header().Set("Content-Disposition", "attachment")
+	}
+	w.WriteHeader(status)
+
+	cw := csv.NewWriter(w)
+
+	if headers != nil {
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+	}
+
+	for row := range rows {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// CsvFile writes the headers and rows as a csv file download with the given
+// status. The filename is suggested to the client in the Content-Disposition header.
+func CsvFile(w http.ResponseWriter, status int, filename string, headers []string, rows iter.Seq[[]string]) error {
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	return Csv(w, status, headers, rows)
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Early Hints
+//
+//
+// ------------------------------------------------------------------
+
+// EarlyHints writes a 103 Early Hints response with Link preload headers,
+// so the browser can fetch critical assets while the page is rendered.
+//
+// Each link is either a url, like "/static/app.css", or a complete Link
+// header value. The "as" attribute of a url is inferred from its extension.
+// The Link headers are also sent with the final response.
+//
+//	EarlyHints(w, []string{"/static/app.css", "/static/app.js"})
+//	return Render(w, "home.html", http.StatusOK, data)
+//
+// .
+func EarlyHints(w http.ResponseWriter, links []string) {
+	if len(links) == 0 {
+		return
+	}
+	for _, link := range links {
+		w.Header().Add("Link", preloadLink(link))
+	}
+	w.WriteHeader(http.StatusEarlyHints)
+}
+
+// preloadLink formats a url as a Link preload header value.
+func preloadLink(link string) string {
+	if strings.HasPrefix(link, "<") {
+		return link
+	}
+
+	path, _, _ := strings.Cut(link, "?")
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".css":
+		return fmt.Sprintf("<%s>; rel=preload; as=style", link)
+	case ".js", ".mjs":
+		return fmt.Sprintf("<%s>; rel=preload; as=script", link)
+	case ".woff", ".woff2", ".ttf", ".otf":
+		return fmt.Sprintf("<%s>; rel=preload; as=font; crossorigin", link)
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp", ".avif", ".svg":
+		return fmt.Sprintf("<%s>; rel=preload; as=image", link)
+	default:
+		return fmt.Sprintf("<%s>; rel=preload", link)
+	}
 }
@@ -0,0 +1,270 @@
+package rio
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tunedmystic/rio/forms"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Json Streaming Responses
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestJsonStream(t *testing.T) {
+	t.Run("JsonStream", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := JsonStream(w, http.StatusCreated, "hi")
+
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusCreated)
+		assert(t, w.Header().Get("Content-Type"), "application/json")
+		assert(t, w.Body.String(), "{\"message\":\"hi\"}\n")
+	})
+
+	t.Run("JsonStream-buffered-error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := JsonStream(w, http.StatusOK, func() {}, WithBuffer())
+
+		assert(t, err != nil, true)
+		assert(t, w.Body.Len(), 0)
+		assert(t, w.Header().Get("Content-Type"), "")
+	})
+
+	t.Run("JsonSeq", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := JsonSeq(w, http.StatusOK, slices.Values([]int{1, 2, 3}))
+
+		assert(t, err, nil)
+		assert(t, w.Body.String(), "[1,2,3]\n")
+	})
+
+	t.Run("JsonSeq-empty", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := JsonSeq(w, http.StatusOK, slices.Values([]int{}))
+
+		assert(t, err, nil)
+		assert(t, w.Body.String(), "[]\n")
+	})
+
+	t.Run("JsonChan", func(t *testing.T) {
+		ch := make(chan string, 2)
+		ch <- "a"
+		ch <- "b"
+		close(ch)
+
+		w := httptest.NewRecorder()
+		err := JsonChan(w, http.StatusOK, ch, WithBuffer())
+
+		assert(t, err, nil)
+		assert(t, w.Body.String(), "[\"a\",\"b\"]\n")
+	})
+
+	t.Run("JsonChan-error-drains", func(t *testing.T) {
+		ch := make(chan any)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer close(ch)
+			ch <- func() {}
+			for i := range 3 {
+				ch <- i
+			}
+		}()
+
+		err := JsonChan(httptest.NewRecorder(), http.StatusOK, ch)
+		assert(t, err != nil, true)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("producer is blocked")
+		}
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Csv Responses
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestCsv(t *testing.T) {
+	rows := slices.Values([][]string{{"1", "alice"}, {"2", "bob, jr"}})
+
+	t.Run("Csv", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := Csv(w, http.StatusOK, []string{"id", "name"}, rows)
+
+		assert(t, err, nil)
+		assert(t, w.Header().Get("Content-Type"), "text/csv; charset=utf-8")
+		assert(t, w.Header().Get("Content-Disposition"), "attachment")
+		assert(t, w.Body.String(), "id,name\n1,alice\n2,\"bob, jr\"\n")
+	})
+
+	t.Run("CsvFile", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := CsvFile(w, http.StatusOK, "users.csv", nil, rows)
+
+		assert(t, err, nil)
+		assert(t, w.Header().Get("Content-Disposition"), "attachment; filename=users.csv")
+		assert(t, w.Body.String(), "1,alice\n2,\"bob, jr\"\n")
+	})
+
+	t.Run("CsvFile-filename", func(t *testing.T) {
+		tests := []struct {
+			filename string
+			want     string
+		}{
+			{"my users.csv", `attachment; filename="my users.csv"`},
+			{`say "hi".csv`, `attachment; filename="say \"hi\".csv"`},
+			{"café.csv", `attachment; filename*=utf-8''caf%C3%A9.csv`},
+		}
+
+		for _, tt := range tests {
+			w := httptest.NewRecorder()
+			CsvFile(w, http.StatusOK, tt.filename, nil, rows)
+			assert(t, w.Header().Get("Content-Disposition"), tt.want)
+		}
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Content Responses
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestServeContent(t *testing.T) {
+	t.Run("ServeReaderAt-range", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Range", "bytes=2-4")
+		w := httptest.NewRecorder()
+
+		ServeReaderAt(w, r, "data.txt", time.Time{}, strings.NewReader("0123456789"), 10)
+
+		assert(t, w.Code, http.StatusPartialContent)
+		assert(t, w.Header().Get("Content-Range"), "bytes 2-4/10")
+		assert(t, w.Body.String(), "234")
+	})
+
+	t.Run("ServeAttachment", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		w := httptest.NewRecorder()
+
+		ServeAttachment(w, r, "report.csv", time.Time{}, strings.NewReader("a,b\n"))
+
+		assert(t, w.Code, http.StatusOK)
+		assert(t, w.Header().Get("Content-Disposition"), "attachment; filename=report.csv")
+		assert(t, w.Header().Get("Accept-Ranges"), "bytes")
+		assert(t, w.Body.String(), "a,b\n")
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Json Envelope Responses
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestJsonEnvelope(t *testing.T) {
+	t.Run("JsonData", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		err := JsonData(w, http.StatusOK, []int{1, 2}, map[string]int{"total": 2})
+
+		assert(t, err, nil)
+		assert(t, w.Body.String(), `{"data":[1,2],"meta":{"total":2}}`)
+	})
+
+	t.Run("JsonErrors", func(t *testing.T) {
+		form := forms.New()
+		form.CleanString("email", "", forms.StrRequired())
+
+		errs := append(form.Errors(), HttpError("slow down", http.StatusTooManyRequests), errors.New("boom"))
+
+		w := httptest.NewRecorder()
+		err := JsonErrors(w, http.StatusBadRequest, errs)
+
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusBadRequest)
+		assert(t, w.Body.String(), `{"errors":[{"message":"cannot be blank","field":"email"},{"message":"slow down","status":429},{"message":"boom"}]}`)
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Early Hints
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestEarlyHints(t *testing.T) {
+	assert(t, preloadLink("/static/app.css?v=1"), "</static/app.css?v=1>; rel=preload; as=style")
+	assert(t, preloadLink("/static/app.js"), "</static/app.js>; rel=preload; as=script")
+	assert(t, preloadLink("/static/inter.woff2"), "</static/inter.woff2>; rel=preload; as=font; crossorigin")
+	assert(t, preloadLink("</a.json>; rel=preload; as=fetch"), "</a.json>; rel=preload; as=fetch")
+
+	w := httptest.NewRecorder()
+	EarlyHints(w, []string{"/static/app.css", "/static/app.js"})
+	assert(t, len(w.Header().Values("Link")), 2)
+
+	t.Run("middleware-status", func(t *testing.T) {
+		defer Logger(defaultLogger)
+
+		var logs, access bytes.Buffer
+		Logger(NewLogger(&logs))
+
+		var captured CapturedResponse
+		sink := func(r *http.Request, res CapturedResponse) {
+			captured = res
+		}
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			EarlyHints(w, []string{"/static/app.css"})
+			w.Write([]byte("page"))
+		})
+		h := LogRequest(AccessLog(&access, "%s")(CaptureResponseTo(10, sink)(handler)))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+		assert(t, strings.Contains(logs.String(), "status=200"), true)
+		assert(t, access.String(), "200\n")
+		assert(t, captured.Status, http.StatusOK)
+	})
+}
@@ -0,0 +1,81 @@
+package rio
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Automatic Methods
+//
+//
+// ------------------------------------------------------------------
+
+// probeMethods are the methods which are checked against the ServeMux
+// to find the methods allowed for a path.
+var probeMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// allowedMethods returns the methods which the mux routes for the request's path.
+//
+// Each method is checked by asking the mux for the handler of a copy of the
+// request with that method, so the result follows the mux's own matching rules.
+// Returns false if no method matches the path.
+func allowedMethods(mux *http.ServeMux, r *http.Request) ([]string, bool) {
+	var methods []string
+
+	for _, method := range probeMethods {
+		probe := *r
+		probe.Method = method
+		if _, pattern := mux.Handler(&probe); pattern != "" {
+			methods = append(methods, method)
+		}
+	}
+	return methods, len(methods) > 0
+}
+
+// autoMethods is a middleware which answers OPTIONS requests with
+// an Allow header, and returns a 405 Method Not Allowed with an
+// Allow header when the path matches but the method does not.
+//
+// Requests which the mux routes, including OPTIONS requests with a
+// registered handler, are passed to the next handler.
+func autoMethods(mux *http.ServeMux, next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		if _, pattern := mux.Handler(r); pattern != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		methods, ok := allowedMethods(mux, r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		methods = append(methods, http.MethodOptions)
+		slices.Sort(methods)
+		w.Header().Set("Allow", strings.Join(slices.Compact(methods), ", "))
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		status := http.StatusMethodNotAllowed
+		writeError(w, r, status, http.StatusText(status))
+	}
+	return http.HandlerFunc(fn)
+}
@@ -0,0 +1,352 @@
+package rio
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Scheduler
+//
+//
+// ------------------------------------------------------------------
+
+// Scheduler runs functions periodically, in the background.
+//
+// Jobs can be scheduled at a fixed interval (Every), at a time of
+// day (At), or with a cron expression (Cron).
+//
+//	sch := NewScheduler()
+//	sch.Every(10*time.Minute, cleanupSessions)
+//	sch.At("03:00", vacuumDatabase)
+//	sch.Cron("*/15 9-17 * * 1-5", syncInventory)
+//
+// .
+type Scheduler struct {
+	mu      sync.Mutex
+	jobs    []*job
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	running bool
+}
+
+// NewScheduler constructs and returns a new *Scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every schedules fn to run at every interval d.
+// The interval must be positive.
+func (s *Scheduler) Every(d time.Duration, fn func()) error {
+	if d <= 0 {
+		return fmt.Errorf("invalid interval %s: must be positive", d)
+	}
+	s.add(fmt.Sprintf("every %s", d), everySchedule(d), fn)
+	return nil
+}
+
+// At schedules fn to run every day at the given time of day.
+// The clock assumes the format "15:04", in local time.
+func (s *Scheduler) At(clock string, fn func()) error {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return fmt.Errorf("invalid clock %q: %w", clock, err)
+	}
+	s.add("at "+clock, atSchedule{hour: t.Hour(), minute: t.Minute()}, fn)
+	return nil
+}
+
+// Cron schedules fn to run according to the given cron expression.
+//
+// The expression contains 5 fields: minute, hour, day of month,
+// month and day of week. Each field supports "*", lists ("1,15"),
+// ranges ("1-5") and steps ("*/10", "0-30/5").
+func (s *Scheduler) Cron(expr string, fn func()) error {
+	cs, err := parseCron(expr)
+	if err != nil {
+		return err
+	}
+	s.add("cron "+expr, cs, fn)
+	return nil
+}
+
+// Start runs all scheduled jobs in the background.
+// Calling Start on a running Scheduler has no effect.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+	s.running = true
+	s.stop = make(chan struct{})
+
+	for i := range s.jobs {
+		s.launch(s.jobs[i])
+	}
+}
+
+// Stop halts all scheduled jobs, and waits for any
+// running jobs to complete.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	close(s.stop)
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// add registers a job. If the Scheduler is running,
+// then the job is started immediately.
+func (s *Scheduler) add(name string, sched schedule, fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j := &job{name: name, sched: sched, fn: fn}
+	s.jobs = append(s.jobs, j)
+
+	if s.running {
+		s.launch(j)
+	}
+}
+
+// launch runs the job loop in a goroutine, until the Scheduler is stopped.
+func (s *Scheduler) launch(j *job) {
+	stop := s.stop
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			now := time.Now()
+			next := j.sched.next(now)
+			if next.IsZero() {
+				LogWarn("scheduled job will never run", slog.String("job", j.name))
+				return
+			}
+			timer := time.NewTimer(next.Sub(now))
+
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+				j.run()
+			}
+		}
+	}()
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Type: job
+//
+//
+// ------------------------------------------------------------------
+
+// job is a function which runs on a schedule.
+type job struct {
+	name  string
+	sched schedule
+	fn    func()
+}
+
+// run calls the job function, and logs any panic that occurs.
+func (j *job) run() {
+	defer func() {
+		if err := recover(); err != nil {
+			LogError(fmt.Errorf("scheduled job panicked: %v", err), slog.String("job", j.name))
+		}
+	}()
+	j.fn()
+}
+
+// schedule computes the next run time of a job.
+type schedule interface {
+	next(time.Time) time.Time
+}
+
+// everySchedule runs at a fixed interval.
+type everySchedule time.Duration
+
+func (e everySchedule) next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// atSchedule runs every day at a time of day.
+type atSchedule struct {
+	hour   int
+	minute int
+}
+
+func (a atSchedule) next(t time.Time) time.Time {
+	n := time.Date(t.Year(), t.Month(), t.Day(), a.hour, a.minute, 0, 0, t.Location())
+	if !n.After(t) {
+		n = n.AddDate(0, 0, 1)
+	}
+	return n
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Cron Expressions
+//
+//
+// ------------------------------------------------------------------
+
+// cronSchedule runs at the times matched by a cron expression.
+// Each field is a bitset of the allowed values.
+type cronSchedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domStar and dowStar track if the day fields are unrestricted.
+	// If both day fields are restricted, then a day matches either field.
+	domStar bool
+	dowStar bool
+}
+
+// cronBounds are the min and max values of each cron field.
+var cronBounds = [5][2]int{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 7},  // day of week (0 or 7 is Sunday)
+}
+
+// parseCron parses a 5-field cron expression.
+func parseCron(expr string) (cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("invalid cron expression %q: expected 5 fields", expr)
+	}
+
+	var bits [5]uint64
+	for i := range fields {
+		b, err := parseCronField(fields[i], cronBounds[i][0], cronBounds[i][1])
+		if err != nil {
+			return cronSchedule{}, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		bits[i] = b
+	}
+
+	// Sunday can be written as 0 or 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return cronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField parses a single cron field into a bitset.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if rng, stepStr, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			part, step = rng, n
+		}
+
+		lo, hi := min, max
+		if part != "*" {
+			loStr, hiStr, isRange := strings.Cut(part, "-")
+
+			n, err := strconv.Atoi(loStr)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			lo, hi = n, n
+
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return 0, fmt.Errorf("invalid range %q", part)
+				}
+			} else if step > 1 {
+				// A single value with a step (eg "5/15") runs until the max.
+				hi = max
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value out of range %q", part)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// next returns the first matching minute after t.
+// If no match is found within 5 years, then the zero time is returned.
+func (c cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay checks if the day of t matches the day fields.
+func (c cronSchedule) matchDay(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,100 @@
+package rio
+
+import (
+	"testing"
+	"time"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Scheduler
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestScheduler(t *testing.T) {
+	t.Run("Every", func(t *testing.T) {
+		sch := NewScheduler()
+		done := make(chan struct{}, 1)
+		err := sch.Every(time.Millisecond, func() {
+			select {
+			case done <- struct{}{}:
+			default:
+			}
+		})
+		assert(t, err, nil)
+		sch.Start()
+		defer sch.Stop()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("job did not run")
+		}
+	})
+
+	t.Run("Every-invalid", func(t *testing.T) {
+		sch := NewScheduler()
+		assert(t, sch.Every(0, func() {}).Error(), "invalid interval 0s: must be positive")
+		assert(t, sch.Every(-time.Second, func() {}) != nil, true)
+		assert(t, len(sch.jobs), 0)
+	})
+
+	t.Run("At", func(t *testing.T) {
+		sch := NewScheduler()
+		assert(t, sch.At("25:00", func() {}) != nil, true)
+		assert(t, sch.At("03:00", func() {}), nil)
+
+		a := atSchedule{hour: 3, minute: 0}
+		now := time.Date(2024, 1, 10, 4, 0, 0, 0, time.UTC)
+		assert(t, a.next(now), time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC))
+
+		now = time.Date(2024, 1, 10, 2, 0, 0, 0, time.UTC)
+		assert(t, a.next(now), time.Date(2024, 1, 10, 3, 0, 0, 0, time.UTC))
+	})
+
+	t.Run("parseCron-errors", func(t *testing.T) {
+		exprs := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"}
+		for _, expr := range exprs {
+			_, err := parseCron(expr)
+			assert(t, err != nil, true)
+		}
+	})
+
+	t.Run("cron-next", func(t *testing.T) {
+		// Wednesday, January 10, 2024.
+		now := time.Date(2024, 1, 10, 10, 7, 30, 0, time.UTC)
+
+		tests := []struct {
+			expr string
+			want time.Time
+		}{
+			{"* * * * *", time.Date(2024, 1, 10, 10, 8, 0, 0, time.UTC)},
+			{"*/15 * * * *", time.Date(2024, 1, 10, 10, 15, 0, 0, time.UTC)},
+			{"0 3 * * *", time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC)},
+			{"30 9 1 * *", time.Date(2024, 2, 1, 9, 30, 0, 0, time.UTC)},
+			{"0 0 * * 0", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+			{"0 0 * * 7", time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+			{"0 12 * 3 1-5", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+			{"0 0 13 * 5", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+			{"0 0 30 2 *", time.Time{}},
+			// Only a bare "*" leaves a day field unrestricted, so a step
+			// in both day fields matches either field.
+			{"0 0 */2 * */3", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+			{"0 0 */10 * 1", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+			{"0 0 */10 * *", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+			{"0 0 * * */3", time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 3)},
+		}
+
+		for _, tt := range tests {
+			cs, err := parseCron(tt.expr)
+			assert(t, err, nil)
+			assert(t, cs.next(now), tt.want)
+		}
+	})
+}
@@ -22,6 +22,9 @@ import (
 type Server struct {
 	mux        *http.ServeMux
 	middleware []func(http.Handler) http.Handler
+	scheduler  *Scheduler
+
+	autoMethods bool
 }
 
 // NewServer constructs and returns a new *Server.
@@ -58,6 +61,26 @@ func (s *Server) Use(middleware ...func(http.Handler) http.Handler) {
 	s.middleware = append(s.middleware, middleware...)
 }
 
+// AutoMethods enables automatic OPTIONS and 405 responses.
+//
+// Using the routes of the ServeMux, OPTIONS requests are answered with an
+// Allow header, and requests which match a path but not a method receive
+// a 405 Method Not Allowed with an Allow header. The allowed methods are
+// found by matching the path against the standard HTTP methods.
+//
+// Routes registered without a method accept any method, and are not affected.
+func (s *Server) AutoMethods() {
+	s.autoMethods = true
+}
+
+// Schedule attaches the Scheduler to the Server.
+//
+// The Scheduler is started when the Server starts serving, and
+// is stopped when the Server stops serving.
+func (s *Server) Schedule(sch *Scheduler) {
+	s.scheduler = sch
+}
+
 // Handler returns the Server as an http.Handler.
 //
 // It wraps the ServeMux with the middleware handlers, and returns
@@ -69,6 +92,10 @@ func (s *Server) Handler() http.Handler {
 	slices.Reverse(s.middleware)
 	var h http.Handler = s.mux
 
+	if s.autoMethods {
+		h = autoMethods(s.mux, h)
+	}
+
 	for i := range s.middleware {
 		m := s.middleware[i]
 		h = m(h)
@@ -78,6 +105,11 @@ func (s *Server) Handler() http.Handler {
 
 // Serve starts an http server on the given address.
 func (s *Server) Serve(addr string) error {
+	if s.scheduler != nil {
+		s.scheduler.Start()
+		defer s.scheduler.Stop()
+	}
+
 	LogInfo("starting server", slog.String("port", addr))
 	return Serve(addr, s.Handler())
 }
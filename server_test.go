@@ -2,6 +2,8 @@ package rio
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"reflect"
 	"runtime"
@@ -25,6 +27,77 @@ func TestServer(t *testing.T) {
 		server := NewServer()
 		assert(t, len(server.middleware), 3)
 	})
+
+	t.Run("AutoMethods", func(t *testing.T) {
+		server := NewServer(func(h http.Handler) http.Handler { return h })
+		server.AutoMethods()
+		ok := func(w http.ResponseWriter, r *http.Request) {}
+		server.HandleFunc("GET /users/{id}", ok)
+		server.HandleFunc("DELETE /users/{userID}", ok)
+		server.HandleFunc("/health", ok)
+		h := server.Handler()
+
+		tests := []struct {
+			method string
+			path   string
+			status int
+			allow  string
+		}{
+			{"GET", "/users/1", http.StatusOK, ""},
+			{"HEAD", "/users/1", http.StatusOK, ""},
+			{"OPTIONS", "/users/1", http.StatusNoContent, "DELETE, GET, HEAD, OPTIONS"},
+			{"POST", "/users/1", http.StatusMethodNotAllowed, "DELETE, GET, HEAD, OPTIONS"},
+			{"POST", "/health", http.StatusOK, ""},
+			{"OPTIONS", "/missing", http.StatusNotFound, ""},
+		}
+
+		for _, tt := range tests {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert(t, w.Code, tt.status)
+			assert(t, w.Header().Get("Allow"), tt.allow)
+		}
+	})
+
+	t.Run("AutoMethods overlapping patterns", func(t *testing.T) {
+		server := NewServer(func(h http.Handler) http.Handler { return h })
+		server.AutoMethods()
+		ok := func(w http.ResponseWriter, r *http.Request) {}
+		server.HandleFunc("GET /{a}/x", ok)
+		server.HandleFunc("POST /x/{b}", ok)
+		h := server.Handler()
+
+		tests := []struct {
+			method string
+			path   string
+			status int
+			allow  string
+		}{
+			{"GET", "/y/x", http.StatusOK, ""},
+			{"POST", "/x/y", http.StatusOK, ""},
+			{"DELETE", "/y/x", http.StatusMethodNotAllowed, "GET, HEAD, OPTIONS"},
+			{"OPTIONS", "/x/y", http.StatusNoContent, "OPTIONS, POST"},
+		}
+
+		for _, tt := range tests {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert(t, w.Code, tt.status)
+			assert(t, w.Header().Get("Allow"), tt.allow)
+		}
+	})
+
+	t.Run("Handle without AutoMethods", func(t *testing.T) {
+		server := NewServer(func(h http.Handler) http.Handler { return h })
+		ok := func(w http.ResponseWriter, r *http.Request) {}
+		server.HandleFunc("GET /{a}/x", ok)
+		server.HandleFunc("POST /x/{b}", ok)
+
+		w := httptest.NewRecorder()
+		server.Handler().ServeHTTP(w, httptest.NewRequest("DELETE", "/y/x", nil))
+		assert(t, w.Code, http.StatusMethodNotAllowed)
+		assert(t, w.Header().Get("Allow"), "GET, HEAD")
+	})
 }
 
 // ------------------------------------------------------------------
@@ -0,0 +1,80 @@
+package rio
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Streaming Responses
+//
+//
+// ------------------------------------------------------------------
+
+// ErrFlushNotSupported is returned by Stream when the
+// ResponseWriter cannot be flushed.
+var ErrFlushNotSupported = errors.New("rio: response writer does not support flushing")
+
+// Stream writes a streaming response, in chunks.
+//
+// The fn is given a send func, which writes a chunk and flushes it to the
+// client immediately. The send func returns the request's context error
+// once the client disconnects, so fn can stop producing chunks.
+//
+// The server's write timeout is lifted for the streaming response.
+// Headers should be set before calling Stream.
+//
+//	return Stream(w, r, func(send func([]byte) error) error {
+//		for event := range events {
+//			if err := send(event); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	})
+//
+// .
+func Stream(w http.ResponseWriter, r *http.Request, fn func(send func([]byte) error) error) error {
+	if !canFlush(w) {
+		return ErrFlushNotSupported
+	}
+
+	rc := http.NewResponseController(w)
+
+	// Long-lived streams should not be cut off by the server's write timeout.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	ctx := r.Context()
+
+	send := func(chunk []byte) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	return fn(send)
+}
+
+// canFlush checks if the ResponseWriter, or any ResponseWriter
+// it wraps, implements http.Flusher.
+func canFlush(w http.ResponseWriter) bool {
+	for {
+		switch t := w.(type) {
+		case http.Flusher:
+			return true
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package rio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Streaming Responses
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestStream(t *testing.T) {
+	t.Run("Stream", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		w := httptest.NewRecorder()
+
+		// Wrapped by LogRequest's writer, to check unwrapping.
+		ww := &logResponseWriter{ResponseWriter: w}
+
+		err := Stream(ww, r, func(send func([]byte) error) error {
+			send([]byte("a"))
+			return send([]byte("b"))
+		})
+
+		assert(t, err, nil)
+		assert(t, w.Flushed, true)
+		assert(t, w.Body.String(), "ab")
+	})
+
+	t.Run("Stream-client-disconnect", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		r := httptest.NewRequest("GET", "/", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		err := Stream(w, r, func(send func([]byte) error) error {
+			send([]byte("a"))
+			cancel()
+			return send([]byte("b"))
+		})
+
+		assert(t, err, context.Canceled)
+		assert(t, w.Body.String(), "a")
+	})
+
+	t.Run("Stream-not-supported", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		w := struct{ http.ResponseWriter }{httptest.NewRecorder()}
+
+		err := Stream(w, r, func(send func([]byte) error) error { return nil })
+		assert(t, err, ErrFlushNotSupported)
+	})
+}
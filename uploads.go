@@ -0,0 +1,509 @@
+package rio
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Uploads
+//
+//
+// ------------------------------------------------------------------
+
+// Uploads handles multipart file uploads.
+//
+// It enforces size limits, sniffs the content type of each file,
+// sanitizes filenames and saves the files to a Storage.
+//
+//	uploads := &Uploads{
+//		MaxFileSize:  5 << 20,
+//		MaxTotalSize: 20 << 20,
+//		AllowedTypes: []string{"image/*", "application/pdf"},
+//		Storage:      NewDiskStorage("./media"),
+//	}
+//
+//	func (a *App) uploadAvatar(w http.ResponseWriter, r *http.Request) error {
+//		if err := a.uploads.Parse(w, r); err != nil {
+//			return err
+//		}
+//		form := forms.New()
+//		form.CleanFile("avatar", a.uploads.File(r, "avatar"), forms.FileRequired())
+//		if !form.IsValid() {
+//			return FormErrorsJson(w, form)
+//		}
+//		file, err := a.uploads.Save(r.Context(), form.CleanedFile("avatar"))
+//		...
+//	}
+//
+// .
+type Uploads struct {
+	// MaxFileSize is the max size of a single file, in bytes.
+	// Zero means no limit.
+	MaxFileSize int64
+
+	// MaxTotalSize is the max size of the request body, in bytes.
+	// Defaults to 32MB.
+	MaxTotalSize int64
+
+	// AllowedTypes are the allowed content types of the files.
+	// A type can end with a wildcard, like "image/*".
+	// An empty list allows all types.
+	AllowedTypes []string
+
+	// Storage is where the files are saved.
+	Storage Storage
+}
+
+// StoredFile describes a file which was saved to a Storage.
+type StoredFile struct {
+	Key          string
+	Filename     string
+	OriginalName string
+	ContentType  string
+	Size         int64
+}
+
+// Parse parses the request's multipart form, limited to MaxTotalSize.
+//
+// If the request body is too large, then a 413 AppError is returned.
+// If the request is not a valid multipart form, then a 400 AppError is returned.
+func (u *Uploads) Parse(w http.ResponseWriter, r *http.Request) error {
+	maxTotal := u.MaxTotalSize
+	if maxTotal <= 0 {
+		maxTotal = maxMemory
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxTotal)
+
+	if err := r.ParseMultipartForm(min(maxTotal, maxMemory)); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return HttpError("upload is too large", http.StatusRequestEntityTooLarge)
+		}
+		return HttpError("invalid multipart form", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// File returns the first file uploaded with the given name,
+// or nil if there is none. The request must be parsed first.
+func (u *Uploads) File(r *http.Request, name string) *multipart.FileHeader {
+	if r.MultipartForm == nil || len(r.MultipartForm.File[name]) == 0 {
+		return nil
+	}
+	return r.MultipartForm.File[name][0]
+}
+
+// Save checks the file against the size and type limits, and saves it to the Storage.
+//
+// If the file is too large, then a 413 AppError is returned.
+// If the file type is not allowed, then a 415 AppError is returned.
+func (u *Uploads) Save(ctx context.Context, fh *multipart.FileHeader) (StoredFile, error) {
+	if u.Storage == nil {
+		return StoredFile{}, errNoStorage
+	}
+	if fh == nil {
+		return StoredFile{}, HttpError("missing file", http.StatusBadRequest)
+	}
+	if u.MaxFileSize > 0 && fh.Size > u.MaxFileSize {
+		return StoredFile{}, HttpError("file is too large", http.StatusRequestEntityTooLarge)
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return StoredFile{}, err
+	}
+	defer f.Close()
+
+	// Sniff the content type from the first 512 bytes.
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return StoredFile{}, err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+
+	if !typeAllowed(contentType, u.AllowedTypes) {
+		return StoredFile{}, HttpError("file type is not allowed", http.StatusUnsupportedMediaType)
+	}
+
+	filename := SanitizeFilename(fh.Filename)
+	key, err := uploadKey(filename)
+	if err != nil {
+		return StoredFile{}, err
+	}
+
+	content := io.MultiReader(bytes.NewReader(head), f)
+	if err := u.Storage.Store(ctx, key, content, contentType); err != nil {
+		return StoredFile{}, fmt.Errorf("failed to store upload: %w", err)
+	}
+
+	return StoredFile{
+		Key:          key,
+		Filename:     filename,
+		OriginalName: fh.Filename,
+		ContentType:  contentType,
+		Size:         fh.Size,
+	}, nil
+}
+
+// typeAllowed checks if the content type matches any of the allowed types.
+func typeAllowed(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	for _, a := range allowed {
+		if prefix, ok := strings.CutSuffix(a, "*"); ok {
+			if strings.HasPrefix(mediaType, prefix) {
+				return true
+			}
+		} else if mediaType == a {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadKey returns a unique storage key for the filename.
+func uploadKey(filename string) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b) + "-" + filename, nil
+}
+
+// SanitizeFilename returns a safe version of a client-provided filename.
+//
+// Any directories are stripped, and characters other than letters,
+// digits, dots, dashes and underscores are replaced with a dash.
+func SanitizeFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	if name == "/" || name == "." {
+		return "file"
+	}
+
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+
+	name = strings.TrimLeft(b.String(), ".")
+	if len(name) > 200 {
+		name = name[len(name)-200:]
+	}
+	if name == "" {
+		return "file"
+	}
+	return name
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Type: Storage
+//
+//
+// ------------------------------------------------------------------
+
+// Storage saves uploaded files.
+//
+// Disk, memory and S3-compatible storages are provided.
+// Other backends can be used by implementing Storage.
+type Storage interface {
+	Store(ctx context.Context, key string, content io.Reader, contentType string) error
+}
+
+// StorageFunc is an adapter to use a function as a Storage.
+type StorageFunc func(ctx context.Context, key string, content io.Reader, contentType string) error
+
+// Store calls f(ctx, key, content, contentType).
+func (f StorageFunc) Store(ctx context.Context, key string, content io.Reader, contentType string) error {
+	return f(ctx, key, content, contentType)
+}
+
+// errNoStorage is returned when Uploads has no Storage.
+var errNoStorage = errors.New("rio: uploads storage is not configured")
+
+// DiskStorage saves files in a directory.
+type DiskStorage struct {
+	dir string
+}
+
+// NewDiskStorage constructs and returns a new *DiskStorage.
+func NewDiskStorage(dir string) *DiskStorage {
+	return &DiskStorage{dir: dir}
+}
+
+// Store writes the content to a file named by the key.
+//
+// If the context is done before the content is written, then
+// the partial file is removed, and the context's error is returned.
+func (d *DiskStorage) Store(ctx context.Context, key string, content io.Reader, contentType string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := filepath.Join(d.dir, filepath.Base(key))
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, ctxReader{ctx, content}); err != nil {
+		f.Close()
+		os.Remove(name)
+		return err
+	}
+	return f.Close()
+}
+
+// ctxReader is a reader which stops reading when the context is done.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c ctxReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
+
+// MemoryStorage saves files in memory. It is useful for tests.
+type MemoryStorage struct {
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+// NewMemoryStorage constructs and returns a new *MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{files: make(map[string][]byte)}
+}
+
+// Store reads the content into memory.
+func (m *MemoryStorage) Store(ctx context.Context, key string, content io.Reader, contentType string) error {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[key] = b
+	return nil
+}
+
+// Get returns the content stored with the key.
+func (m *MemoryStorage) Get(key string) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	b, ok := m.files[key]
+	return b, ok
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Type: S3Storage
+//
+//
+// ------------------------------------------------------------------
+
+// S3Config configures an S3Storage.
+type S3Config struct {
+	// Endpoint is the base url of the service, like "https://s3.us-east-1.amazonaws.com",
+	// or the url of an S3-compatible service, like "http://localhost:9000".
+	Endpoint string
+
+	// Region is the region of the bucket, like "us-east-1".
+	Region string
+
+	// Bucket is the name of the bucket.
+	Bucket string
+
+	// Prefix is prepended to the keys of the stored files, like "uploads/".
+	Prefix string
+
+	// AccessKey and SecretKey are the credentials of the service.
+	AccessKey string
+	SecretKey string
+
+	// Client is the http client used for requests.
+	// Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// S3Storage saves files to an S3-compatible object storage, like
+// AWS S3, MinIO or Cloudflare R2. Requests are signed with AWS
+// Signature Version 4, and objects are addressed with path-style urls.
+//
+//	storage := NewS3Storage(S3Config{
+//		Endpoint:  "https://s3.us-east-1.amazonaws.com",
+//		Region:    "us-east-1",
+//		Bucket:    "media",
+//		AccessKey: os.Getenv("S3_ACCESS_KEY"),
+//		SecretKey: os.Getenv("S3_SECRET_KEY"),
+//	})
+//
+// .
+type S3Storage struct {
+	cfg S3Config
+}
+
+// NewS3Storage constructs and returns a new *S3Storage.
+func NewS3Storage(cfg S3Config) *S3Storage {
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	return &S3Storage{cfg: cfg}
+}
+
+// Store uploads the content as an object named by the prefix and key.
+//
+// The content is read into memory, as the request must be signed with
+// a hash of the payload. The size of uploads is limited by Uploads.
+func (s *S3Storage) Store(ctx context.Context, key string, content io.Reader, contentType string) error {
+	body, err := io.ReadAll(ctxReader{ctx, content})
+	if err != nil {
+		return err
+	}
+
+	path := "/" + s3Escape(s.cfg.Bucket) + "/" + s3Escape(s.cfg.Prefix+key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.cfg.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, path, body, time.Now().UTC())
+
+	res, err := s.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("s3: put %s: %s: %s", key, res.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// sign adds the AWS Signature Version 4 headers to the request.
+// The path must be the escaped path of the request's url.
+func (s *S3Storage) sign(req *http.Request, path string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := amzDate[:8]
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// The signed headers, sorted by name.
+	headers := [][2]string{
+		{"content-type", req.Header.Get("Content-Type")},
+		{"host", req.URL.Host},
+		{"x-amz-content-sha256", payloadHash},
+		{"x-amz-date", amzDate},
+	}
+	if headers[0][1] == "" {
+		headers = headers[1:]
+	}
+
+	var canonicalHeaders strings.Builder
+	names := make([]string, len(headers))
+	for i, h := range headers {
+		canonicalHeaders.WriteString(h[0] + ":" + strings.TrimSpace(h[1]) + "\n")
+		names[i] = h[0]
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := sigV4Key(s.cfg.SecretKey, date, s.cfg.Region, "s3")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.cfg.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// sigV4Key derives the signing key of AWS Signature Version 4.
+func sigV4Key(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of the data with the key.
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// sha256Hex returns the hex encoded SHA256 hash of the data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// s3Escape escapes an object key for a url path, as S3 expects. Every byte
+// except the unreserved characters and "/" is percent-encoded.
+func s3Escape(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			c == '-', c == '.', c == '_', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
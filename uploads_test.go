@@ -0,0 +1,169 @@
+package rio
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// Uploads
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestUploads(t *testing.T) {
+	newRequest := func(filename, content string) *http.Request {
+		body := &bytes.Buffer{}
+		mw := multipart.NewWriter(body)
+		fw, _ := mw.CreateFormFile("doc", filename)
+		fw.Write([]byte(content))
+		mw.Close()
+
+		r := httptest.NewRequest("POST", "/", body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		return r
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		storage := NewMemoryStorage()
+		u := &Uploads{AllowedTypes: []string{"text/*"}, Storage: storage}
+		r := newRequest("../../my report.txt", "hello world")
+		w := httptest.NewRecorder()
+
+		assert(t, u.Parse(w, r), nil)
+
+		file, err := u.Save(r.Context(), u.File(r, "doc"))
+		assert(t, err, nil)
+		assert(t, file.Filename, "my-report.txt")
+		assert(t, file.OriginalName, "my report.txt")
+		assert(t, file.ContentType, "text/plain; charset=utf-8")
+		assert(t, file.Size, int64(11))
+		assert(t, strings.HasSuffix(file.Key, "-my-report.txt"), true)
+
+		content, ok := storage.Get(file.Key)
+		assert(t, ok, true)
+		assert(t, string(content), "hello world")
+	})
+
+	t.Run("Save-type-not-allowed", func(t *testing.T) {
+		u := &Uploads{AllowedTypes: []string{"image/*"}, Storage: NewMemoryStorage()}
+		r := newRequest("fake.png", "not an image")
+		assert(t, u.Parse(httptest.NewRecorder(), r), nil)
+
+		_, err := u.Save(r.Context(), u.File(r, "doc"))
+		assert(t, err, HttpError("file type is not allowed", http.StatusUnsupportedMediaType))
+	})
+
+	t.Run("Save-file-too-large", func(t *testing.T) {
+		u := &Uploads{MaxFileSize: 4, Storage: NewMemoryStorage()}
+		r := newRequest("a.txt", "hello")
+		assert(t, u.Parse(httptest.NewRecorder(), r), nil)
+
+		_, err := u.Save(r.Context(), u.File(r, "doc"))
+		assert(t, err, HttpError("file is too large", http.StatusRequestEntityTooLarge))
+	})
+
+	t.Run("Parse-too-large", func(t *testing.T) {
+		u := &Uploads{MaxTotalSize: 16, Storage: NewMemoryStorage()}
+		r := newRequest("a.txt", strings.Repeat("a", 64))
+
+		err := u.Parse(httptest.NewRecorder(), r)
+		assert(t, err, HttpError("upload is too large", http.StatusRequestEntityTooLarge))
+	})
+
+	t.Run("SanitizeFilename", func(t *testing.T) {
+		assert(t, SanitizeFilename(`C:\Users\me\photo (1).jpg`), "photo--1-.jpg")
+		assert(t, SanitizeFilename(".htaccess"), "htaccess")
+		assert(t, SanitizeFilename(""), "file")
+		assert(t, SanitizeFilename("/"), "file")
+	})
+
+	t.Run("DiskStorage-canceled", func(t *testing.T) {
+		dir := t.TempDir()
+		storage := NewDiskStorage(dir)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := storage.Store(ctx, "a.txt", strings.NewReader("hello"), "text/plain")
+		assert(t, err, context.Canceled)
+
+		_, err = os.Stat(filepath.Join(dir, "a.txt"))
+		assert(t, os.IsNotExist(err), true)
+	})
+}
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// S3Storage
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestS3Storage(t *testing.T) {
+	t.Run("Store", func(t *testing.T) {
+		var got *http.Request
+		var body string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			got, body = r, string(b)
+		}))
+		defer srv.Close()
+
+		storage := NewS3Storage(S3Config{
+			Endpoint:  srv.URL + "/",
+			Region:    "us-east-1",
+			Bucket:    "media",
+			Prefix:    "uploads/",
+			AccessKey: "AKIDEXAMPLE",
+			SecretKey: "secret",
+		})
+		err := storage.Store(context.Background(), "a b.txt", strings.NewReader("hello"), "text/plain")
+		assert(t, err, nil)
+
+		assert(t, got.Method, http.MethodPut)
+		assert(t, got.URL.EscapedPath(), "/media/uploads/a%20b.txt")
+		assert(t, got.Header.Get("Content-Type"), "text/plain")
+		assert(t, got.Header.Get("X-Amz-Content-Sha256"), sha256Hex([]byte("hello")))
+		assert(t, strings.HasPrefix(got.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), true)
+		assert(t, strings.Contains(got.Header.Get("Authorization"), "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date"), true)
+		assert(t, body, "hello")
+	})
+
+	t.Run("Store-error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "AccessDenied", http.StatusForbidden)
+		}))
+		defer srv.Close()
+
+		storage := NewS3Storage(S3Config{Endpoint: srv.URL, Region: "us-east-1", Bucket: "media"})
+		err := storage.Store(context.Background(), "a.txt", strings.NewReader("hello"), "text/plain")
+		assert(t, err.Error(), "s3: put a.txt: 403 Forbidden: AccessDenied")
+	})
+
+	t.Run("sigV4Key", func(t *testing.T) {
+		// The example from the AWS Signature Version 4 documentation.
+		key := sigV4Key("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20150830", "us-east-1", "iam")
+		assert(t, hex.EncodeToString(key), "c4afb1cc5771d871763a393e44b703571b55cc28424d1a5e86da6ed3c154a4b9")
+	})
+}
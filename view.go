@@ -1,11 +1,21 @@
 package rio
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"io/fs"
+	"log/slog"
+	"maps"
 	"net/http"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"text/template/parse"
 
 	"github.com/tunedmystic/rio/format"
 )
@@ -32,6 +42,27 @@ func Render(w http.ResponseWriter, page string, status int, data any) error {
 	return defaultView.Render(w, page, status, data)
 }
 
+// RenderRequest writes a template to the http.ResponseWriter,
+// with the request-scoped template context.
+func RenderRequest(w http.ResponseWriter, r *http.Request, page string, status int, data any) error {
+	return defaultView.RenderRequest(w, r, page, status, data)
+}
+
+// RenderOK writes a template with a 200 OK status, using the default view.
+func RenderOK(w http.ResponseWriter, page string, data any) error {
+	return defaultView.RenderOK(w, page, data)
+}
+
+// Render404 writes the "404" template with a 404 status, using the default view.
+func Render404(w http.ResponseWriter, data any) error {
+	return defaultView.Render404(w, data)
+}
+
+// Render500 writes the "500" template with a 500 status, using the default view.
+func Render500(w http.ResponseWriter, data any) error {
+	return defaultView.Render500(w, data)
+}
+
 // ------------------------------------------------------------------
 //
 //
@@ -45,18 +76,125 @@ type ViewOpt func(*View)
 
 // WithFuncMap adds the functions in the given funcMap to the View.
 //
-// If the View contains a funcMap function with the same name,
-// then it will not be added.
+// A function with the same name as a default function, like
+// "date" or "title", replaces the default.
 func WithFuncMap(funcMap template.FuncMap) ViewOpt {
 	return func(v *View) {
-		for key := range funcMap {
-			if _, ok := v.funcMap[key]; !ok {
-				v.funcMap[key] = funcMap[key]
-			}
-		}
+		maps.Copy(v.funcMap, funcMap)
+	}
+}
+
+// WithDelims sets the action delimiters of the templates, which
+// default to "{{" and "}}".
+//
+// This is useful when templates embed frontend syntax, like Vue
+// or Angular, which collides with the default delimiters.
+func WithDelims(left, right string) ViewOpt {
+	return func(v *View) {
+		v.leftDelim = left
+		v.rightDelim = right
+	}
+}
+
+// WithExtensions sets the file extensions of the templates to load,
+// which defaults to ".html".
+//
+//	WithExtensions(".html", ".tmpl", ".gohtml")
+//
+// .
+func WithExtensions(exts ...string) ViewOpt {
+	return func(v *View) {
+		v.extensions = exts
+	}
+}
+
+// WithIgnore adds glob patterns of files and directories to skip when
+// loading the templates. A pattern is matched against the full path and
+// against the base name, using path.Match.
+//
+//	WithIgnore("drafts", "*.test.html")
+//
+// .
+func WithIgnore(patterns ...string) ViewOpt {
+	return func(v *View) {
+		v.ignore = append(v.ignore, patterns...)
 	}
 }
 
+// WithStreaming executes templates directly to the http.ResponseWriter,
+// instead of buffering the output first.
+//
+// This halves the memory used to render very large pages. The tradeoff
+// is that an error after the first byte cannot change the status, and the
+// client receives a partial page. The error is logged, and Render returns nil,
+// so that no error response is appended to the page. An error before the
+// first byte is handled like a buffered render.
+func WithStreaming() ViewOpt {
+	return func(v *View) {
+		v.streaming = true
+	}
+}
+
+// WithMinify enables minification of the rendered html. Comments are
+// removed and whitespace is collapsed, except within pre, textarea,
+// script and style elements.
+//
+// Only the responses written to an http.ResponseWriter are minified.
+// Streaming output, and the output of RenderToString and RenderToBytes,
+// which are used for plain text and emails, is not minified.
+func WithMinify(enabled bool) ViewOpt {
+	return func(v *View) {
+		v.minify = enabled
+	}
+}
+
+// WithErrorTemplate sets a template to render, with a 500 status, when a
+// template is not found or fails to execute. The template receives an ErrorPageData.
+//
+// Without a fallback, a failed render writes nothing, and it is up to
+// the handler to write a response.
+func WithErrorTemplate(page string) ViewOpt {
+	return func(v *View) {
+		v.errorPage = page
+	}
+}
+
+// WithRenderFallback sets a func which writes the response when a
+// template is not found or fails to execute. It takes precedence over WithErrorTemplate.
+//
+//	WithRenderFallback(func(w http.ResponseWriter, err error) {
+//		Http500(w)
+//	})
+//
+// .
+func WithRenderFallback(fn func(http.ResponseWriter, error)) ViewOpt {
+	return func(v *View) {
+		v.fallback = fn
+	}
+}
+
+// WithRequestContext adds funcs which derive template values from the request,
+// like a CSRF token, flash messages or the current user.
+//
+// The values are exposed to every template rendered with RenderRequest.
+//
+//	WithRequestContext(func(r *http.Request) map[string]any {
+//		return map[string]any{
+//			"csrf": csrfToken(r),
+//			"user": currentUser(r.Context()),
+//		}
+//	})
+//
+// .
+func WithRequestContext(fns ...RequestContextFunc) ViewOpt {
+	return func(v *View) {
+		v.contextFuncs = append(v.contextFuncs, fns...)
+	}
+}
+
+// RequestContextFunc is a function which derives template values from a request.
+type RequestContextFunc func(*http.Request) map[string]any
+
 // ------------------------------------------------------------------
 //
 //
@@ -65,10 +203,29 @@ func WithFuncMap(funcMap template.FuncMap) ViewOpt {
 //
 // ------------------------------------------------------------------
 
+// ErrTemplateNotFound is returned when rendering a template which does not exist.
+var ErrTemplateNotFound = errors.New("template not found")
+
 // View is a collection of html templates for rendering.
 type View struct {
-	templates *template.Template
-	funcMap   template.FuncMap
+	templates    *template.Template
+	funcMap      template.FuncMap
+	contextFuncs []RequestContextFunc
+	leftDelim    string
+	rightDelim   string
+	extensions   []string
+	ignore       []string
+	streaming    bool
+	minify       bool
+	errorPage    string
+	fallback     func(http.ResponseWriter, error)
+
+	// names and sources are the template files, in the order they are parsed.
+	names   []string
+	sources map[string]string
+
+	pagesMu sync.Mutex
+	pages   map[string]*template.Template
 }
 
 // NewView constructs and returns a new *View.
@@ -85,22 +242,420 @@ func NewView(templatesFS fs.FS, opts ...ViewOpt) *View {
 
 // Render writes a template to the http.ResponseWriter.
 func (v *View) Render(w http.ResponseWriter, page string, status int, data any) error {
+	return v.execute(w, v.templates, page, status, data)
+}
+
+// RenderOK writes a template with a 200 OK status.
+func (v *View) RenderOK(w http.ResponseWriter, page string, data any) error {
+	return v.Render(w, page, http.StatusOK, data)
+}
+
+// Render404 writes the "404" template with a 404 Not Found status.
+// If the View has no "404" template, then a plain text response is written.
+func (v *View) Render404(w http.ResponseWriter, data any) error {
+	return v.renderStatus(w, http.StatusNotFound, data)
+}
+
+// Render500 writes the "500" template with a 500 Internal Server Error status.
+// If the View has no "500" template, then a plain text response is written.
+func (v *View) Render500(w http.ResponseWriter, data any) error {
+	return v.renderStatus(w, http.StatusInternalServerError, data)
+}
+
+// renderStatus writes the template named after the status code,
+// falling back to the status text.
+func (v *View) renderStatus(w http.ResponseWriter, status int, data any) error {
+	page := v.resolve(strconv.Itoa(status))
+	if !v.Has(page) {
+		HttpStatus(w, status, http.StatusText(status))
+		return nil
+	}
+	return v.Render(w, page, status, data)
+}
+
+// RequestData is the data given to templates rendered with RenderRequest.
+type RequestData struct {
+	// Request is the current request.
+	Request *http.Request
+
+	// Path is the path of the current request.
+	Path string
+
+	// Ctx contains the values of the View's request context funcs.
+	Ctx map[string]any
+
+	// Data is the data given by the handler.
+	Data any
+}
+
+// RenderRequest writes a template to the http.ResponseWriter,
+// with the request-scoped template context.
+//
+// The template receives a RequestData, so the handler's data is available
+// as {{ .Data }}, and the request context values as {{ .Ctx.key }}.
+func (v *View) RenderRequest(w http.ResponseWriter, r *http.Request, page string, status int, data any) error {
+	return v.Render(w, page, status, v.requestData(r, data))
+}
+
+// requestData builds the RequestData, by running the request context funcs.
+// Later funcs override the values of earlier funcs.
+func (v *View) requestData(r *http.Request, data any) RequestData {
+	rd := RequestData{
+		Request: r,
+		Path:    r.URL.Path,
+		Ctx:     make(map[string]any),
+		Data:    data,
+	}
+	for _, fn := range v.contextFuncs {
+		maps.Copy(rd.Ctx, fn(r))
+	}
+	return rd
+}
+
+// RenderPage writes a page, applied to a layout, to the http.ResponseWriter.
+//
+// The layout is the document shell, and the page defines the blocks that
+// the layout renders. The page's blocks override the layout's default blocks.
+// The ".html" extension can be omitted from the names.
+//
+//	<!-- layouts/base.html -->
+//	<html><body>{{ block "content" . }}{{ end }}</body></html>
+//
+//	<!-- pages/home.html -->
+//	{{ define "content" }}<h1>Home</h1>{{ end }}
+//
+//	view.RenderPage(w, "layouts/base", "pages/home", http.StatusOK, data)
+//
+// .
+func (v *View) RenderPage(w http.ResponseWriter, layout, page string, status int, data any) error {
+	t, name, err := v.pageTemplate(layout, page)
+	if err != nil {
+		return v.executeFailed(w, layout, err)
+	}
+	return v.execute(w, t, name, status, data)
+}
+
+// Has returns true if the View contains the named template.
+func (v *View) Has(page string) bool {
+	return v.templates.Lookup(page) != nil
+}
+
+// Validate checks that every template only references templates
+// which exist, and returns an error describing all missing references.
+//
+// Calling Validate at startup reports template bugs before the first
+// request renders them. Unknown functions and syntax errors are already
+// reported when the View is constructed.
+//
+//	view := NewView(templatesFS)
+//	if err := view.Validate(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// .
+func (v *View) Validate() error {
+	var errs []error
+
+	templates := v.templates.Templates()
+	slices.SortFunc(templates, func(a, b *template.Template) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	for _, t := range templates {
+		if t.Tree == nil {
+			continue
+		}
+		for _, ref := range templateRefs(t.Tree.Root) {
+			if v.templates.Lookup(ref) == nil {
+				errs = append(errs, fmt.Errorf("template %q references undefined template %q", t.Name(), ref))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// templateRefs returns the names of the templates invoked within the node.
+func templateRefs(node parse.Node) []string {
+	var refs []string
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			refs = append(refs, templateRefs(child)...)
+		}
+	case *parse.TemplateNode:
+		refs = append(refs, n.Name)
+	case *parse.IfNode:
+		refs = append(refs, templateRefs(n.List)...)
+		refs = append(refs, templateRefs(n.ElseList)...)
+	case *parse.RangeNode:
+		refs = append(refs, templateRefs(n.List)...)
+		refs = append(refs, templateRefs(n.ElseList)...)
+	case *parse.WithNode:
+		refs = append(refs, templateRefs(n.List)...)
+		refs = append(refs, templateRefs(n.ElseList)...)
+	}
+
+	return refs
+}
+
+// RenderToBytes executes a template and returns the output.
+//
+// This is useful for emails, background jobs and tests, where
+// the output is not written to an http.ResponseWriter.
+func (v *View) RenderToBytes(page string, data any) ([]byte, error) {
+	if err := v.checkExists(v.templates, page); err != nil {
+		return nil, err
+	}
+
 	buf := getBuffer()
 	defer putBuffer(buf)
 
-	// Write the template to the buffer first.
 	if err := v.templates.ExecuteTemplate(buf, page, data); err != nil {
-		return err
+		return nil, err
+	}
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// RenderToString executes a template and returns the output as a string.
+func (v *View) RenderToString(page string, data any) (string, error) {
+	if err := v.checkExists(v.templates, page); err != nil {
+		return "", err
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := v.templates.ExecuteTemplate(buf, page, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// execute writes the named template to the http.ResponseWriter.
+//
+// The output is buffered, unless the View is streaming.
+//
+// If the template does not exist or fails to execute, and the View has a
+// fallback, then the error is logged, the fallback writes the response, and
+// nil is returned. Without a fallback, the error is returned, and nothing is
+// written, so that the handler's error handling writes the response.
+//
+// If a streaming template fails after the output has been sent, then the
+// response cannot be changed, so the error is logged, and nil is returned.
+func (v *View) execute(w http.ResponseWriter, t *template.Template, name string, status int, data any) error {
+	if err := v.checkExists(t, name); err != nil {
+		return v.executeFailed(w, name, err)
+	}
+
+	if v.streaming {
+		sw := &streamWriter{w: w, status: status}
+		err := t.ExecuteTemplate(sw, name, data)
+		switch {
+		case err == nil:
+			sw.writeHeader()
+			return nil
+		case sw.wroteHeader:
+			LogError(err, slog.String("template", name))
+			return nil
+		}
+		return v.executeFailed(w, name, err)
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	// Write the template to the buffer first.
+	if err := t.ExecuteTemplate(buf, name, data); err != nil {
+		return v.executeFailed(w, name, err)
 	}
 
 	w.WriteHeader(status)
 
 	// Write the contents of the buffer to the http.ResponseWriter.
-	buf.WriteTo(w)
+	v.writeOutput(w, buf)
 
 	return nil
 }
 
+// executeFailed handles a template which was not found or failed to execute,
+// before any output was written. If the View has a fallback, then the error is logged, the fallback
+// writes the response, and nil is returned. Otherwise, the error is returned.
+func (v *View) executeFailed(w http.ResponseWriter, name string, err error) error {
+	if v.fallback == nil && v.errorPage == "" {
+		return err
+	}
+	LogError(err, slog.String("template", name))
+	v.renderFallback(w, err)
+	return nil
+}
+
+// streamWriter writes the status before the first write of a streaming
+// template, so that a template which fails before any output can still
+// write an error response.
+type streamWriter struct {
+	w           http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// writeHeader writes the status, if it has not been written.
+func (sw *streamWriter) writeHeader() {
+	if !sw.wroteHeader {
+		sw.wroteHeader = true
+		sw.w.WriteHeader(sw.status)
+	}
+}
+
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	sw.writeHeader()
+	return sw.w.Write(p)
+}
+
+// writeOutput writes the rendered output, minifying it if enabled.
+func (v *View) writeOutput(w io.Writer, buf *bytes.Buffer) {
+	if !v.minify {
+		buf.WriteTo(w)
+		return
+	}
+
+	out := getBuffer()
+	defer putBuffer(out)
+
+	minifyHtml(out, buf.Bytes())
+	out.WriteTo(w)
+}
+
+// renderFallback writes the fallback response for a failed render.
+//
+// If the error template also fails, then a plain 500 Internal Server Error is written.
+func (v *View) renderFallback(w http.ResponseWriter, err error) {
+	if v.fallback != nil {
+		v.fallback(w, err)
+		return
+	}
+
+	status := http.StatusInternalServerError
+	data := ErrorPageData{
+		Status:  status,
+		Title:   http.StatusText(status),
+		Message: http.StatusText(status),
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := v.templates.ExecuteTemplate(buf, v.resolve(v.errorPage), data); err != nil {
+		LogError(err, slog.String("template", v.errorPage))
+		Http500(w)
+		return
+	}
+
+	w.WriteHeader(status)
+	v.writeOutput(w, buf)
+}
+
+// checkExists returns a descriptive error if the named template does not exist.
+func (v *View) checkExists(t *template.Template, name string) error {
+	if t.Lookup(name) != nil {
+		return nil
+	}
+
+	names := slices.Sorted(maps.Keys(v.sources))
+	if len(names) == 0 {
+		return fmt.Errorf("%w: %q (the view has no templates)", ErrTemplateNotFound, name)
+	}
+	return fmt.Errorf("%w: %q (available templates: %s)", ErrTemplateNotFound, name, strings.Join(names, ", "))
+}
+
+// pageTemplate returns the template set for a page applied to a layout,
+// along with the resolved layout name.
+//
+// The set is parsed from all templates, followed by the layout and the page,
+// so that the layout's default blocks are restored and then overridden by
+// the page's blocks. Sets are cached.
+func (v *View) pageTemplate(layout, page string) (*template.Template, string, error) {
+	layout, page = v.resolve(layout), v.resolve(page)
+	key := layout + "|" + page
+
+	v.pagesMu.Lock()
+	defer v.pagesMu.Unlock()
+
+	if t, ok := v.pages[key]; ok {
+		return t, layout, nil
+	}
+
+	if _, ok := v.sources[page]; !ok {
+		return nil, "", fmt.Errorf("page template %q not found", page)
+	}
+	if _, ok := v.sources[layout]; !ok {
+		return nil, "", fmt.Errorf("layout template %q not found", layout)
+	}
+
+	t, err := v.parseTemplates(append(slices.Clone(v.names), layout, page)...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if v.pages == nil {
+		v.pages = make(map[string]*template.Template)
+	}
+	v.pages[key] = t
+	return t, layout, nil
+}
+
+// parseTemplates parses the named template files into a new template set.
+func (v *View) parseTemplates(names ...string) (*template.Template, error) {
+	t := template.New("").Delims(v.leftDelim, v.rightDelim)
+	for _, name := range names {
+		if _, err := t.New(name).Funcs(v.funcMap).Parse(v.sources[name]); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// resolve returns the template name, adding a template
+// extension if the name does not exist without it.
+func (v *View) resolve(name string) string {
+	if _, ok := v.sources[name]; ok {
+		return name
+	}
+	for _, ext := range v.extensions {
+		if _, ok := v.sources[name+ext]; ok {
+			return name + ext
+		}
+	}
+	return name
+}
+
+// isTemplate checks if the file has one of the template extensions.
+func (v *View) isTemplate(name string) bool {
+	for _, ext := range v.extensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIgnored checks if the path matches one of the ignore patterns.
+func (v *View) isIgnored(name string) bool {
+	for _, pattern := range v.ignore {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // constructView constructs and returns a *View.
 //
 // All html templates within templatesFS are parsed and loaded.
@@ -109,48 +664,134 @@ func (v *View) Render(w http.ResponseWriter, page string, status int, data any)
 // more can be added with the ViewOpt functions.
 func constructView(templatesFS fs.FS, opts ...ViewOpt) (*View, error) {
 	v := &View{
-		templates: template.New(""),
-		funcMap:   template.FuncMap{},
+		templates:  template.New(""),
+		funcMap:    template.FuncMap{},
+		sources:    map[string]string{},
+		extensions: []string{".html"},
 	}
 
 	// Set the default template functions.
+	maps.Copy(v.funcMap, format.FuncMap())
+	v.funcMap["asset"] = assetPath
+	v.funcMap["dict"] = dict
+	v.funcMap["list"] = list
+	v.funcMap["markdown"] = Markdown
+	v.funcMap["node"] = renderNode
+	v.funcMap["partial"] = v.partial
 	v.funcMap["safe"] = safeHtml
-	v.funcMap["title"] = format.Title
-	v.funcMap["titlefirst"] = format.TitleFirst
 
 	// Configure the View with with ViewOpt funcs, if any.
 	for i := range opts {
 		opts[i](v)
 	}
 
-	// Parse and load all templates from the given filesystem.
+	// Read all templates from the given filesystem.
 	//
 	// Walk the templateFS filesystem, recursively.
-	err := fs.WalkDir(templatesFS, ".", func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(templatesFS, ".", func(name string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Process all Html files.
-		if !d.IsDir() && strings.HasSuffix(path, ".html") {
+		// Skip ignored files and directories.
+		if name != "." && v.isIgnored(name) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		// Process all template files.
+		if !d.IsDir() && v.isTemplate(name) {
 			// Read the file.
-			fileBytes, err := fs.ReadFile(templatesFS, path)
+			fileBytes, err := fs.ReadFile(templatesFS, name)
 			if err != nil {
 				return err
 			}
 
-			// Create new template.
-			t := v.templates.New(path).Funcs(v.funcMap)
-
-			// Parse the template.
-			if _, err := t.Parse(string(fileBytes)); err != nil {
-				return err
-			}
+			v.names = append(v.names, name)
+			v.sources[name] = string(fileBytes)
 		}
 		return nil
 	})
+	if err != nil {
+		return v, err
+	}
+
+	// Parse and load all templates.
+	v.templates, err = v.parseTemplates(v.names...)
+	if err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+// ------------------------------------------------------------------
+//
+//
+// Layered Filesystem
+//
+//
+// ------------------------------------------------------------------
+
+// LayerFS combines several filesystems into one, where files in later
+// layers override files in earlier layers with the same path.
+//
+// This is useful to ship reusable templates, like error pages or emails,
+// as an embedded filesystem, and let the app override some of them.
+//
+//	Templates(LayerFS(errorpages.FS, appTemplates))
+//
+// .
+func LayerFS(layers ...fs.FS) fs.FS {
+	return layerFS(layers)
+}
+
+// layerFS is a stack of filesystems, searched from the last to the first.
+type layerFS []fs.FS
+
+// Open opens the named file from the last layer which contains it.
+func (l layerFS) Open(name string) (fs.File, error) {
+	for i := len(l) - 1; i >= 0; i-- {
+		f, err := l[i].Open(name)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir merges the entries of the named directory from all layers.
+// Entries in later layers replace entries with the same name.
+func (l layerFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries := map[string]fs.DirEntry{}
+	found := false
+
+	for _, layer := range l {
+		list, err := fs.ReadDir(layer, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, entry := range list {
+			entries[entry.Name()] = entry
+		}
+	}
+
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
 
-	return v, err
+	return slices.SortedFunc(maps.Values(entries), func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	}), nil
 }
 
 // ------------------------------------------------------------------
@@ -185,6 +826,80 @@ func WrapMap[T any](val map[string]T) func() map[string]T {
 	}
 }
 
+// dict builds a map from a list of key and value pairs, so that
+// several named values can be passed to a template.
+//
+//	{{ template "card" dict "title" .Title "user" .User }}
+//
+// .
+func dict(pairs ...any) (map[string]any, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict: odd number of arguments")
+	}
+
+	m := make(map[string]any, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is not a string", pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
+// list builds a slice from the given items.
+//
+//	{{ range list "home" "about" "blog" }}...{{ end }}
+//
+// .
+func list(items ...any) []any {
+	return items
+}
+
+// partial executes the named template with the data, and returns the output.
+// Unlike the "template" action, the name can be dynamic and the extension
+// can be omitted.
+//
+//	{{ partial "partials/card" (dict "title" .Title) }}
+//
+// .
+func (v *View) partial(name string, data any) (template.HTML, error) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := v.templates.ExecuteTemplate(buf, v.resolve(name), data); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
+
+// Node is a value which renders itself as html, like a component
+// built in Go code.
+type Node interface {
+	Render(w io.Writer) error
+}
+
+// renderNode renders the Node to an HTML fragment, so that components
+// can be embedded in templates.
+//
+//	{{ node .Sidebar }}
+//
+// .
+func renderNode(n Node) (template.HTML, error) {
+	if n == nil {
+		return "", nil
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := n.Render(buf); err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
+
 // safeHtml converts a string into an HTML fragment, so that
 // it can be rendered verbatim in the template.
 func safeHtml(content string) template.HTML {
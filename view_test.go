@@ -0,0 +1,363 @@
+package rio
+
+import (
+	"errors"
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+//
+//
+//
+// View
+//
+//
+//
+// ------------------------------------------------------------------
+// ------------------------------------------------------------------
+
+func TestView(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html": {Data: []byte(`<title>{{ block "title" . }}Site{{ end }}</title><main>{{ block "content" . }}{{ end }}</main>`)},
+		"pages/home.html":   {Data: []byte(`{{ define "content" }}<h1>Home {{ . }}</h1>{{ end }}`)},
+		"pages/about.html":  {Data: []byte(`{{ define "title" }}About{{ end }}{{ define "content" }}<h1>About</h1>{{ end }}`)},
+		"hello.html":        {Data: []byte(`Hello {{ . }}`)},
+	}
+
+	t.Run("Render", func(t *testing.T) {
+		view := NewView(fsys)
+		w := httptest.NewRecorder()
+
+		err := view.Render(w, "hello.html", http.StatusCreated, "alice")
+
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusCreated)
+		assert(t, w.Body.String(), "Hello alice")
+	})
+
+	t.Run("RenderOK", func(t *testing.T) {
+		view := NewView(fsys)
+		w := httptest.NewRecorder()
+
+		err := view.RenderOK(w, "hello.html", "dave")
+
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusOK)
+		assert(t, w.Body.String(), "Hello dave")
+	})
+
+	t.Run("Has", func(t *testing.T) {
+		view := NewView(fsys)
+		assert(t, view.Has("hello.html"), true)
+		assert(t, view.Has("missing.html"), false)
+	})
+
+	t.Run("Render-not-found", func(t *testing.T) {
+		view := NewView(fsys)
+		w := httptest.NewRecorder()
+
+		err := view.Render(w, "missing.html", http.StatusOK, nil)
+
+		assert(t, errors.Is(err, ErrTemplateNotFound), true)
+		assert(t, err.Error(), `template not found: "missing.html" (available templates: hello.html, layouts/base.html, pages/about.html, pages/home.html)`)
+		assert(t, w.Body.Len(), 0)
+
+		// The handler's error handling writes the response once.
+		w = httptest.NewRecorder()
+		h := MakeHandler(func(w http.ResponseWriter, r *http.Request) error {
+			return view.Render(w, "missing.html", http.StatusOK, nil)
+		})
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		assert(t, w.Code, http.StatusInternalServerError)
+		assert(t, w.Body.String(), "Internal Server Error\n")
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		view := NewView(fsys)
+		assert(t, view.Validate(), nil)
+
+		view = NewView(fstest.MapFS{
+			"a.html": {Data: []byte(`{{ template "missing" }}{{ if . }}{{ template "b.html" }}{{ end }}`)},
+			"b.html": {Data: []byte(`{{ range . }}{{ else }}{{ template "gone" . }}{{ end }}`)},
+		})
+		err := view.Validate()
+		assert(t, err.Error(), "template \"a.html\" references undefined template \"missing\"\n"+
+			"template \"b.html\" references undefined template \"gone\"")
+	})
+
+	t.Run("Render404-Render500", func(t *testing.T) {
+		view := NewView(fstest.MapFS{
+			"404.html": {Data: []byte(`Not found: {{ . }}`)},
+		})
+
+		w := httptest.NewRecorder()
+		err := view.Render404(w, "/missing")
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusNotFound)
+		assert(t, w.Body.String(), "Not found: /missing")
+
+		w = httptest.NewRecorder()
+		err = view.Render500(w, nil)
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusInternalServerError)
+		assert(t, w.Body.String(), "Internal Server Error\n")
+	})
+
+	t.Run("RenderToString", func(t *testing.T) {
+		view := NewView(fsys)
+
+		str, err := view.RenderToString("hello.html", "bob")
+		assert(t, err, nil)
+		assert(t, str, "Hello bob")
+
+		b, err := view.RenderToBytes("hello.html", "carol")
+		assert(t, err, nil)
+		assert(t, string(b), "Hello carol")
+	})
+
+	t.Run("RenderRequest", func(t *testing.T) {
+		reqFS := fstest.MapFS{
+			"page.html": {Data: []byte(`{{ .Path }} {{ .Ctx.csrf }} {{ .Ctx.user }} {{ .Data }}`)},
+		}
+		view := NewView(reqFS, WithRequestContext(
+			func(r *http.Request) map[string]any { return map[string]any{"csrf": "tok", "user": "anon"} },
+			func(r *http.Request) map[string]any { return map[string]any{"user": "alice"} },
+		))
+
+		w := httptest.NewRecorder()
+		err := view.RenderRequest(w, httptest.NewRequest("GET", "/home", nil), "page.html", http.StatusOK, "hi")
+
+		assert(t, err, nil)
+		assert(t, w.Body.String(), "/home tok alice hi")
+	})
+
+	t.Run("WithFuncMap", func(t *testing.T) {
+		view := NewView(fstest.MapFS{
+			"page.html": {Data: []byte(`{{ title "a" }} {{ shout "b" }} {{ slugify "C d" }}`)},
+		}, WithFuncMap(template.FuncMap{
+			"title": func(s string) string { return "custom " + s },
+			"shout": strings.ToUpper,
+		}))
+
+		str, err := view.RenderToString("page.html", nil)
+		assert(t, err, nil)
+		assert(t, str, "custom a B c-d")
+	})
+
+	t.Run("WithDelims", func(t *testing.T) {
+		view := NewView(fstest.MapFS{
+			"vue.html": {Data: []byte(`<p>{{ msg }}</p><p>[[ . ]]</p>`)},
+		}, WithDelims("[[", "]]"))
+
+		str, err := view.RenderToString("vue.html", "hi")
+		assert(t, err, nil)
+		assert(t, str, "<p>{{ msg }}</p><p>hi</p>")
+	})
+
+	t.Run("WithExtensions-WithIgnore", func(t *testing.T) {
+		view := NewView(fstest.MapFS{
+			"a.tmpl":          {Data: []byte(`a`)},
+			"b.gohtml":        {Data: []byte(`b`)},
+			"c.html":          {Data: []byte(`c`)},
+			"d.test.tmpl":     {Data: []byte(`d`)},
+			"drafts/e.gohtml": {Data: []byte(`{{ broken`)},
+		}, WithExtensions(".tmpl", ".gohtml"), WithIgnore("drafts", "*.test.tmpl"))
+
+		assert(t, view.Has("a.tmpl"), true)
+		assert(t, view.Has("b.gohtml"), true)
+		assert(t, view.Has("c.html"), false)
+		assert(t, view.Has("d.test.tmpl"), false)
+		assert(t, view.Has("drafts/e.gohtml"), false)
+	})
+
+	t.Run("WithStreaming", func(t *testing.T) {
+		defer Logger(defaultLogger)
+		Logger(NewLogger(io.Discard))
+
+		view := NewView(fstest.MapFS{
+			"page.html": {Data: []byte(`start {{ .Missing }}`)},
+		}, WithStreaming())
+
+		w := httptest.NewRecorder()
+		err := view.Render(w, "page.html", http.StatusOK, "not a struct")
+
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusOK)
+		assert(t, w.Body.String(), "start ")
+	})
+
+	t.Run("WithStreaming-before-output", func(t *testing.T) {
+		defer Logger(defaultLogger)
+		Logger(NewLogger(io.Discard))
+
+		fsys := fstest.MapFS{
+			"page.html":  {Data: []byte(`{{ .Missing }} end`)},
+			"error.html": {Data: []byte(`{{ .Status }} {{ .Title }}`)},
+		}
+
+		w := httptest.NewRecorder()
+		err := NewView(fsys, WithStreaming()).Render(w, "page.html", http.StatusOK, "not a struct")
+		assert(t, err != nil, true)
+		assert(t, w.Body.Len(), 0)
+
+		w = httptest.NewRecorder()
+		err = NewView(fsys, WithStreaming(), WithErrorTemplate("error")).Render(w, "page.html", http.StatusOK, "not a struct")
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusInternalServerError)
+		assert(t, w.Body.String(), "500 Internal Server Error")
+	})
+
+	t.Run("WithMinify", func(t *testing.T) {
+		view := NewView(fstest.MapFS{
+			"page.html": {Data: []byte("<div>\n  <!-- {{ . }} -->\n  <p>{{ . }}</p>\n</div>")},
+		}, WithMinify(true))
+
+		w := httptest.NewRecorder()
+		err := view.Render(w, "page.html", http.StatusOK, "hi")
+		assert(t, err, nil)
+		assert(t, w.Body.String(), "<div> <p>hi</p> </div>")
+
+		// Plain text and emails keep their whitespace.
+		str, err := view.RenderToString("page.html", "hi")
+		assert(t, err, nil)
+		assert(t, str, "<div>\n  \n  <p>hi</p>\n</div>")
+	})
+
+	t.Run("RenderPage", func(t *testing.T) {
+		view := NewView(fsys)
+
+		// Render a template first, to check that pages can still be built.
+		view.Render(httptest.NewRecorder(), "hello.html", http.StatusOK, nil)
+
+		w := httptest.NewRecorder()
+		err := view.RenderPage(w, "layouts/base", "pages/home", http.StatusOK, "!")
+		assert(t, err, nil)
+		assert(t, w.Body.String(), "<title>Site</title><main><h1>Home !</h1></main>")
+
+		w = httptest.NewRecorder()
+		err = view.RenderPage(w, "layouts/base.html", "pages/about.html", http.StatusOK, nil)
+		assert(t, err, nil)
+		assert(t, w.Body.String(), "<title>About</title><main><h1>About</h1></main>")
+	})
+
+	t.Run("RenderPage-not-found", func(t *testing.T) {
+		view := NewView(fsys)
+
+		err := view.RenderPage(httptest.NewRecorder(), "layouts/base", "pages/missing", http.StatusOK, nil)
+		assert(t, err.Error(), `page template "pages/missing" not found`)
+
+		err = view.RenderPage(httptest.NewRecorder(), "layouts/missing", "pages/home", http.StatusOK, nil)
+		assert(t, err.Error(), `layout template "layouts/missing" not found`)
+	})
+
+	t.Run("WithErrorTemplate", func(t *testing.T) {
+		defer Logger(defaultLogger)
+		Logger(NewLogger(io.Discard))
+
+		view := NewView(fstest.MapFS{
+			"page.html":  {Data: []byte(`start {{ .Missing }}`)},
+			"error.html": {Data: []byte(`{{ .Status }} {{ .Title }}`)},
+		}, WithErrorTemplate("error"))
+
+		w := httptest.NewRecorder()
+		err := view.Render(w, "page.html", http.StatusOK, "not a struct")
+
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusInternalServerError)
+		assert(t, w.Body.String(), "500 Internal Server Error")
+
+		// A missing template also renders the error template.
+		w = httptest.NewRecorder()
+		err = view.Render(w, "missing.html", http.StatusOK, nil)
+
+		assert(t, err, nil)
+		assert(t, w.Code, http.StatusInternalServerError)
+		assert(t, w.Body.String(), "500 Internal Server Error")
+	})
+
+	t.Run("WithRenderFallback", func(t *testing.T) {
+		defer Logger(defaultLogger)
+		Logger(NewLogger(io.Discard))
+
+		var fallbackErr error
+		view := NewView(fstest.MapFS{
+			"page.html": {Data: []byte(`start {{ .Missing }}`)},
+		}, WithErrorTemplate("missing"), WithRenderFallback(func(w http.ResponseWriter, err error) {
+			fallbackErr = err
+			HttpStatus(w, http.StatusServiceUnavailable, "unavailable")
+		}))
+
+		w := httptest.NewRecorder()
+		err := view.Render(w, "page.html", http.StatusOK, "not a struct")
+
+		assert(t, err, nil)
+		assert(t, fallbackErr != nil, true)
+		assert(t, w.Code, http.StatusServiceUnavailable)
+	})
+
+	t.Run("LayerFS", func(t *testing.T) {
+		lib := fstest.MapFS{
+			"errors/404.html": {Data: []byte(`lib 404`)},
+			"errors/500.html": {Data: []byte(`lib 500`)},
+		}
+		app := fstest.MapFS{
+			"errors/404.html": {Data: []byte(`app 404`)},
+			"home.html":       {Data: []byte(`home`)},
+		}
+		view := NewView(LayerFS(lib, app))
+
+		for page, want := range map[string]string{
+			"errors/404.html": "app 404",
+			"errors/500.html": "lib 500",
+			"home.html":       "home",
+		} {
+			str, err := view.RenderToString(page, nil)
+			assert(t, err, nil)
+			assert(t, str, want)
+		}
+	})
+
+	t.Run("dict-list-partial", func(t *testing.T) {
+		view := NewView(fstest.MapFS{
+			"card.html": {Data: []byte(`<b>{{ .title }}</b>{{ range .tags }}[{{ . }}]{{ end }}`)},
+			"page.html": {Data: []byte(`{{ partial "card" (dict "title" . "tags" (list "a" "b")) }}`)},
+			"bad.html":  {Data: []byte(`{{ dict "title" }}`)},
+		})
+
+		str, err := view.RenderToString("page.html", "<Hi>")
+		assert(t, err, nil)
+		assert(t, str, "<b>&lt;Hi&gt;</b>[a][b]")
+
+		_, err = view.RenderToString("bad.html", nil)
+		assert(t, err != nil, true)
+	})
+
+	t.Run("node", func(t *testing.T) {
+		view := NewView(fstest.MapFS{
+			"page.html": {Data: []byte(`<div>{{ node . }}</div>`)},
+		})
+
+		str, err := view.RenderToString("page.html", testNode("<p>hi</p>"))
+		assert(t, err, nil)
+		assert(t, str, "<div><p>hi</p></div>")
+
+		str, err = view.RenderToString("page.html", nil)
+		assert(t, err, nil)
+		assert(t, str, "<div></div>")
+	})
+}
+
+// testNode is a Node which renders a fixed html string.
+type testNode string
+
+func (n testNode) Render(w io.Writer) error {
+	_, err := io.WriteString(w, string(n))
+	return err
+}